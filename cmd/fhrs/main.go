@@ -0,0 +1,426 @@
+// Command fhrs queries the Food Hygiene Rating Scheme API from the command
+// line.
+//
+// Usage:
+//
+//	fhrs [flags] get <fhrsid>
+//	fhrs [flags] search [-name name] [-postcode postcode] [-near lat,lon] [-distance miles] [-rating rating]
+//	fhrs [flags] ratings
+//	fhrs [flags] authorities
+//
+// The flags are:
+//
+//	-format table|json|csv
+//		How to print results. Defaults to table.
+//	-lang en|cy
+//		The language of the results. Defaults to en.
+//	-timeout duration
+//		How long to wait for the API. Defaults to 15s.
+//
+// The exit status is 0 on success, 2 for bad usage, 3 if nothing was found,
+// 4 if the API returned an error and 1 for anything else.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Exit statuses.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitAPIError = 4
+)
+
+const usage = `Usage:
+  fhrs [flags] get <fhrsid>
+  fhrs [flags] search [-name name] [-postcode postcode] [-near lat,lon] [-distance miles] [-rating rating]
+  fhrs [flags] ratings
+  fhrs [flags] authorities
+
+Flags:
+`
+
+// errUsage is returned when the command line is invalid. The reason has
+// already been printed.
+var errUsage = errors.New("Invalid usage")
+
+// errNoResults is returned when a search matches nothing, and exits with the
+// same status as an unknown FHRSID.
+var errNoResults = fmt.Errorf("No establishments found: %w", fhrs.ErrNotFound)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments, returning the exit status.
+// Options are applied to the client after those set by flags.
+func run(args []string, stdout, stderr io.Writer, opts ...fhrs.Option) int {
+	flags := flag.NewFlagSet("fhrs", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	format := flags.String("format", "table", "output `format`: table, json or csv")
+	lang := flags.String("lang", "en", "`language` of the results: en or cy")
+	timeout := flags.Duration("timeout", 15*time.Second, "how long to wait for the API")
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	w, err := newWriter(*format, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	language, err := parseLanguage(*lang)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	client, err := fhrs.NewClient(append([]fhrs.Option{fhrs.WithTimeout(*timeout)}, opts...)...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	if err := client.SetLanguage(language); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	cmd, args := flags.Arg(0), flags.Args()[1:]
+
+	var res result
+	ctx := context.Background()
+
+	switch cmd {
+	case "get":
+		res, err = get(ctx, client, args, stderr)
+	case "search":
+		res, err = search(ctx, client, args, stderr)
+	case "ratings":
+		res, err = ratings(ctx, client, args, stderr)
+	case "authorities":
+		res, err = authorities(ctx, client, args, stderr)
+	default:
+		fmt.Fprintf(stderr, "Unknown command %q\n", cmd)
+		flags.Usage()
+		return exitUsage
+	}
+
+	if err != nil {
+		return exitStatus(err, stderr)
+	}
+
+	if err := w.write(res); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// exitStatus prints err and returns the matching exit status.
+func exitStatus(err error, stderr io.Writer) int {
+	if err == errUsage {
+		return exitUsage
+	}
+
+	fmt.Fprintln(stderr, err)
+
+	var apiErr fhrs.APIError
+	switch {
+	case errors.Is(err, fhrs.ErrNotFound), errors.Is(err, fhrs.ErrEmptyResponse):
+		return exitNotFound
+	case errors.As(err, &apiErr):
+		return exitAPIError
+	}
+
+	return exitError
+}
+
+func parseLanguage(s string) (fhrs.APILanguage, error) {
+	switch strings.ToLower(s) {
+	case "en", "en-gb":
+		return fhrs.LanguageEnglish, nil
+	case "cy", "cy-gb":
+		return fhrs.LanguageCymraeg, nil
+	}
+
+	return 0, fmt.Errorf("Unknown language %q", s)
+}
+
+// subcommand returns a FlagSet for the named subcommand.
+func subcommand(name, args string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: fhrs %s %s\n", name, args)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+func get(ctx context.Context, client *fhrs.Client, args []string, stderr io.Writer) (result, error) {
+	flags := subcommand("get", "<fhrsid>", stderr)
+	if err := flags.Parse(args); err != nil {
+		return result{}, errUsage
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return result{}, errUsage
+	}
+
+	id := flags.Arg(0)
+	if _, err := strconv.Atoi(id); err != nil {
+		fmt.Fprintf(stderr, "Invalid FHRSID %q\n", id)
+		return result{}, errUsage
+	}
+
+	establishment, err := client.Establishments.GetByIDContext(ctx, id)
+	if err != nil {
+		return result{}, err
+	}
+
+	return establishmentsResult(establishment, []fhrs.Establishment{*establishment}), nil
+}
+
+func search(ctx context.Context, client *fhrs.Client, args []string, stderr io.Writer) (result, error) {
+	flags := subcommand("search", "[flags]", stderr)
+	name := flags.String("name", "", "business `name` to match")
+	postcode := flags.String("postcode", "", "`postcode` or address to match")
+	near := flags.String("near", "", "find establishments near `lat,lon`")
+	distance := flags.Int("distance", 1, "maximum distance in `miles` from -near")
+	rating := flags.String("rating", "", "`rating` to match: 4, >=4, <=2 or a key such as Exempt")
+	sort := flags.String("sort", "", "`order` of results, such as rating, desc_rating, alpha or distance")
+	page := flags.Int("page", 1, "page `number` to return")
+	pageSize := flags.Int("page-size", 20, "`number` of results per page")
+
+	if err := flags.Parse(args); err != nil {
+		return result{}, errUsage
+	}
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return result{}, errUsage
+	}
+
+	b := fhrs.NewSearch().Name(*name).Address(*postcode).Page(*page, *pageSize)
+
+	if *near != "" {
+		latitude, longitude, err := parseLocation(*near)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return result{}, errUsage
+		}
+
+		b.Near(latitude, longitude, *distance)
+	}
+
+	if *rating != "" {
+		parseRating(b, *rating)
+	}
+
+	if *sort != "" {
+		option, err := fhrs.ParseSortOption(*sort)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return result{}, errUsage
+		}
+
+		b.SortBy(option)
+	}
+
+	params, err := b.Build()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return result{}, errUsage
+	}
+
+	establishments, err := client.Establishments.SearchContext(ctx, params)
+	if err != nil {
+		return result{}, err
+	}
+
+	if len(establishments.Establishments) == 0 {
+		return result{}, errNoResults
+	}
+
+	return establishmentsResult(establishments, establishments.Establishments), nil
+}
+
+// parseLocation parses a location given as "lat,lon".
+func parseLocation(s string) (latitude, longitude float64, err error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Invalid location %q, expected lat,lon", s)
+	}
+
+	if latitude, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err != nil {
+		return 0, 0, fmt.Errorf("Invalid latitude %q", parts[0])
+	}
+
+	if longitude, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err != nil {
+		return 0, 0, fmt.Errorf("Invalid longitude %q", parts[1])
+	}
+
+	return latitude, longitude, nil
+}
+
+// parseRating adds a rating filter to b. A score may be prefixed with >= or
+// <= to compare, and anything else is used as a rating key.
+func parseRating(b *fhrs.SearchBuilder, s string) {
+	at := func(prefix string) (int, bool) {
+		if !strings.HasPrefix(s, prefix) {
+			return 0, false
+		}
+
+		score, err := strconv.Atoi(strings.TrimPrefix(s, prefix))
+		return score, err == nil
+	}
+
+	if score, ok := at(">="); ok {
+		b.RatingAtLeast(score)
+	} else if score, ok := at("<="); ok {
+		b.RatingAtMost(score)
+	} else if score, ok := at(""); ok {
+		b.RatingEquals(score)
+	} else {
+		b.Rating(s)
+	}
+}
+
+func ratings(ctx context.Context, client *fhrs.Client, args []string, stderr io.Writer) (result, error) {
+	flags := subcommand("ratings", "", stderr)
+	if err := flags.Parse(args); err != nil {
+		return result{}, errUsage
+	}
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return result{}, errUsage
+	}
+
+	ratings, err := client.Ratings.GetContext(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{
+		value:   ratings,
+		headers: []string{"ID", "Name", "Key", "Key Name", "Scheme"},
+	}
+
+	for _, r := range ratings.Ratings {
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(r.RatingID),
+			r.RatingName,
+			r.RatingKey,
+			r.RatingKeyName,
+			strconv.Itoa(r.SchemeTypeID),
+		})
+	}
+
+	return res, nil
+}
+
+func authorities(ctx context.Context, client *fhrs.Client, args []string, stderr io.Writer) (result, error) {
+	flags := subcommand("authorities", "", stderr)
+	if err := flags.Parse(args); err != nil {
+		return result{}, errUsage
+	}
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return result{}, errUsage
+	}
+
+	authorities, err := client.Authorities.GetContext(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	res := result{
+		value:   authorities,
+		headers: []string{"ID", "Code", "Name", "Region", "Establishments", "Last Published"},
+	}
+
+	for _, a := range authorities.Authorities {
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(a.LocalAuthorityID),
+			a.LocalAuthorityIDCode,
+			a.Name,
+			a.RegionName,
+			strconv.Itoa(a.EstablishmentCount),
+			formatDate(a.LastPublishedDate),
+		})
+	}
+
+	return res, nil
+}
+
+// establishmentsResult returns a result printing value as JSON and the
+// establishments as rows.
+func establishmentsResult(value interface{}, establishments []fhrs.Establishment) result {
+	res := result{
+		value:   value,
+		headers: []string{"FHRSID", "Name", "Address", "Post Code", "Rating", "Rating Date", "Authority"},
+	}
+
+	for _, e := range establishments {
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(e.FHRSID),
+			e.BusinessName,
+			joinNonEmpty(", ", e.AddressLine1, e.AddressLine2, e.AddressLine3, e.AddressLine4),
+			e.PostCode,
+			e.RatingValue,
+			formatDate(e.RatingDate),
+			e.LocalAuthorityName,
+		})
+	}
+
+	return res
+}
+
+func formatDate(t fhrs.Timestamp) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Time().Format("2006-01-02")
+}
+
+func joinNonEmpty(sep string, s ...string) string {
+	var parts []string
+	for _, v := range s {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+
+	return strings.Join(parts, sep)
+}
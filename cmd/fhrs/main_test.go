@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const establishmentBody = `{
+  "FHRSID": 82940,
+  "BusinessName": "The Pizza Place",
+  "AddressLine1": "1 High Street",
+  "AddressLine3": "Portsmouth",
+  "PostCode": "PO1 1AA",
+  "RatingValue": "5",
+  "RatingKey": "fhrs_5_en-gb",
+  "RatingDate": "2019-05-01T00:00:00",
+  "LocalAuthorityName": "Portsmouth"
+}`
+
+func getTestEnv() (*httptest.Server, *httprouter.Router) {
+	router := httprouter.New()
+	return httptest.NewServer(router), router
+}
+
+// runTest runs the command against server, returning the exit status, stdout
+// and stderr.
+func runTest(server *httptest.Server, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr, fhrs.WithBaseURL(server.URL+"/"))
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGet(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if p.ByName("id") != "82940" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, establishmentBody)
+	})
+
+	code, stdout, stderr := runTest(server, "get", "82940")
+	if code != exitOK {
+		t.Fatalf("Expected exit status %d but got %d: %s", exitOK, code, stderr)
+	}
+
+	for _, s := range []string{"FHRSID", "82940", "The Pizza Place", "1 High Street, Portsmouth", "2019-05-01"} {
+		if !strings.Contains(stdout, s) {
+			t.Errorf("Expected output to contain %q but got:\n%s", s, stdout)
+		}
+	}
+
+	code, _, _ = runTest(server, "get", "1")
+	if code != exitNotFound {
+		t.Errorf("Expected exit status %d but got %d", exitNotFound, code)
+	}
+}
+
+func TestGet_JSON(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, establishmentBody)
+	})
+
+	code, stdout, stderr := runTest(server, "-format", "json", "get", "82940")
+	if code != exitOK {
+		t.Fatalf("Expected exit status %d but got %d: %s", exitOK, code, stderr)
+	}
+
+	var establishment fhrs.Establishment
+	if err := json.Unmarshal([]byte(stdout), &establishment); err != nil {
+		t.Fatal(err)
+	}
+
+	if establishment.FHRSID != 82940 || establishment.BusinessName != "The Pizza Place" {
+		t.Errorf("Unexpected establishment %+v", establishment)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	expected := map[string]string{
+		"name":              "pizza",
+		"address":           "PO1",
+		"latitude":          "50.8",
+		"longitude":         "-1.09",
+		"maxDistanceLimit":  "2",
+		"ratingKey":         "4",
+		"ratingOperatorKey": "GreaterThanOrEqual",
+		"pageNumber":        "1",
+		"pageSize":          "20",
+	}
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		q := r.URL.Query()
+		for k, v := range expected {
+			if actual := q.Get(k); actual != v {
+				t.Errorf("Expected %s to be %q but got %q", k, v, actual)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "establishments": [`+establishmentBody+`] }`)
+	})
+
+	code, stdout, stderr := runTest(server, "-format", "csv", "search",
+		"-name", "pizza", "-postcode", "PO1", "-near", "50.8,-1.09", "-distance", "2", "-rating", ">=4")
+	if code != exitOK {
+		t.Fatalf("Expected exit status %d but got %d: %s", exitOK, code, stderr)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected a header and 1 row but got %d records", len(records))
+	}
+
+	if records[1][0] != "82940" || records[1][4] != "5" {
+		t.Errorf("Unexpected row %q", records[1])
+	}
+}
+
+func TestSearch_NoResults(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "establishments": [] }`)
+	})
+
+	if code, _, _ := runTest(server, "search", "-name", "nothing"); code != exitNotFound {
+		t.Errorf("Expected exit status %d but got %d", exitNotFound, code)
+	}
+}
+
+func TestEmptyResponse(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	empty := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	router.GET("/Establishments/:id", empty)
+	router.GET("/Establishments", empty)
+	router.GET("/Ratings", empty)
+	router.GET("/Authorities", empty)
+
+	for _, args := range [][]string{{"get", "82940"}, {"search", "-name", "pizza"}, {"ratings"}, {"authorities"}} {
+		if code, _, stderr := runTest(server, args...); code != exitNotFound {
+			t.Errorf("Expected exit status %d for %s but got %d: %s", exitNotFound, args[0], code, stderr)
+		}
+	}
+}
+
+func TestRatings_Welsh(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		if lang := r.Header.Get("Accept-Language"); lang != "cy-GB" {
+			t.Errorf("Expected Accept-Language to be cy-GB but got %s", lang)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [{ "ratingId": 12, "ratingName": "5", "ratingKey": "fhrs_5_cy-gb", "ratingKeyName": "5", "schemeTypeId": 1 }] }`)
+	})
+
+	code, stdout, stderr := runTest(server, "-lang", "cy", "ratings")
+	if code != exitOK {
+		t.Fatalf("Expected exit status %d but got %d: %s", exitOK, code, stderr)
+	}
+
+	if !strings.Contains(stdout, "fhrs_5_cy-gb") {
+		t.Errorf("Expected output to contain the rating key but got:\n%s", stdout)
+	}
+}
+
+func TestAuthorities_APIError(t *testing.T) {
+	server, router := getTestEnv()
+	defer server.Close()
+
+	router.GET("/Authorities", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, `{ "Message": "An error has occurred." }`)
+	})
+
+	code, _, stderr := runTest(server, "authorities")
+	if code != exitAPIError {
+		t.Errorf("Expected exit status %d but got %d", exitAPIError, code)
+	}
+
+	if !strings.Contains(stderr, "An error has occurred.") {
+		t.Errorf("Expected the API error to be printed but got %q", stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	server, _ := getTestEnv()
+	defer server.Close()
+
+	cases := [][]string{
+		{},
+		{"unknown"},
+		{"-format", "xml", "ratings"},
+		{"-lang", "fr", "ratings"},
+		{"get"},
+		{"get", "abc"},
+		{"search", "-near", "50.8"},
+		{"search", "-rating", "9"},
+		{"search", "-sort", "ratting"},
+	}
+
+	for _, args := range cases {
+		if code, _, _ := runTest(server, args...); code != exitUsage {
+			t.Errorf("Expected exit status %d for %q but got %d", exitUsage, args, code)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// result is the output of a command. JSON output prints value as returned by
+// the API, while table and CSV output print the rows.
+type result struct {
+	value   interface{}
+	headers []string
+	rows    [][]string
+}
+
+type writer interface {
+	write(res result) error
+}
+
+func newWriter(format string, w io.Writer) (writer, error) {
+	switch strings.ToLower(format) {
+	case "table":
+		return tableWriter{w}, nil
+	case "json":
+		return jsonWriter{w}, nil
+	case "csv":
+		return csvWriter{w}, nil
+	}
+
+	return nil, fmt.Errorf("Unknown format %q", format)
+}
+
+type tableWriter struct {
+	w io.Writer
+}
+
+func (t tableWriter) write(res result) error {
+	tw := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(res.headers, "\t"))
+	for _, row := range res.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+type jsonWriter struct {
+	w io.Writer
+}
+
+func (j jsonWriter) write(res result) error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res.value)
+}
+
+type csvWriter struct {
+	w io.Writer
+}
+
+func (c csvWriter) write(res result) error {
+	cw := csv.NewWriter(c.w)
+
+	if err := cw.Write(res.headers); err != nil {
+		return err
+	}
+
+	if err := cw.WriteAll(res.rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
@@ -0,0 +1,142 @@
+package fhrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// AuthoritiesService encapsulates the Authorities methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#Authorities
+type AuthoritiesService service
+
+// Authorities is a list of local authorities.
+type Authorities struct {
+	Authorities []Authority `json:"authorities"`
+	Meta        Meta        `json:"meta"`
+	Links       []Link      `json:"links"`
+}
+
+// Authority is a local authority responsible for inspecting establishments.
+//
+// LocalAuthorityID is the value expected by SearchParams.LocalAuthorityID.
+type Authority struct {
+	LocalAuthorityID     int       `json:"LocalAuthorityId"`
+	LocalAuthorityIDCode string    `json:"LocalAuthorityIdCode"`
+	Name                 string    `json:"Name"`
+	FriendlyName         string    `json:"FriendlyName"`
+	URL                  string    `json:"Url"`
+	SchemeURL            string    `json:"SchemeUrl"`
+	Email                string    `json:"Email"`
+	RegionName           string    `json:"RegionName"`
+	FileName             string    `json:"FileName"`
+	FileNameWelsh        string    `json:"FileNameWelsh"`
+	EstablishmentCount   int       `json:"EstablishmentCount"`
+	CreationDate         Timestamp `json:"CreationDate"`
+	LastPublishedDate    Timestamp `json:"LastPublishedDate"`
+	SchemeType           int       `json:"SchemeType"`
+	Links                []Link    `json:"links"`
+}
+
+// BasicAuthorities is a list of local authorities in the basic format.
+type BasicAuthorities struct {
+	Authorities []BasicAuthority `json:"authorities"`
+	Meta        Meta             `json:"meta"`
+	Links       []Link           `json:"links"`
+}
+
+// BasicAuthority is the reduced set of authority details returned by the
+// basic endpoints.
+type BasicAuthority struct {
+	LocalAuthorityID     int    `json:"LocalAuthorityId"`
+	LocalAuthorityIDCode string `json:"LocalAuthorityIdCode"`
+	Name                 string `json:"Name"`
+	EstablishmentCount   int    `json:"EstablishmentCount"`
+	SchemeType           int    `json:"SchemeType"`
+	Links                []Link `json:"links"`
+}
+
+// Get returns the details of all local authorities.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Authorities
+func (s *AuthoritiesService) Get() (*Authorities, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *AuthoritiesService) GetContext(ctx context.Context) (*Authorities, error) {
+	var authorities *Authorities
+	if err := s.client.get(ctx, "Authorities", &authorities); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
+
+// GetPage returns a single page of local authorities.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Authorities-pageNumber-pageSize
+func (s *AuthoritiesService) GetPage(pageNumber, pageSize int) (*Authorities, error) {
+	return s.GetPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// GetPageContext is like GetPage but uses ctx for the request.
+func (s *AuthoritiesService) GetPageContext(ctx context.Context, pageNumber, pageSize int) (*Authorities, error) {
+	var authorities *Authorities
+	if err := s.client.get(ctx, fmt.Sprintf("Authorities/%d/%d", pageNumber, pageSize), &authorities); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
+
+// GetByID returns the local authority with the given ID.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Authorities-id
+func (s *AuthoritiesService) GetByID(id string) (*Authority, error) {
+	return s.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but uses ctx for the request.
+func (s *AuthoritiesService) GetByIDContext(ctx context.Context, id string) (*Authority, error) {
+	var authority *Authority
+	if err := s.client.get(ctx, fmt.Sprintf("Authorities/%s", id), &authority); err != nil {
+		return nil, err
+	}
+
+	return authority, nil
+}
+
+// Basic returns all local authorities in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Authorities-basic
+func (s *AuthoritiesService) Basic() (*BasicAuthorities, error) {
+	return s.BasicContext(context.Background())
+}
+
+// BasicContext is like Basic but uses ctx for the request.
+func (s *AuthoritiesService) BasicContext(ctx context.Context) (*BasicAuthorities, error) {
+	var authorities *BasicAuthorities
+	if err := s.client.get(ctx, "Authorities/basic", &authorities); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
+
+// BasicPage returns a single page of local authorities in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Authorities-basic-pageNumber-pageSize
+func (s *AuthoritiesService) BasicPage(pageNumber, pageSize int) (*BasicAuthorities, error) {
+	return s.BasicPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// BasicPageContext is like BasicPage but uses ctx for the request.
+func (s *AuthoritiesService) BasicPageContext(ctx context.Context, pageNumber, pageSize int) (*BasicAuthorities, error) {
+	var authorities *BasicAuthorities
+	if err := s.client.get(ctx, fmt.Sprintf("Authorities/basic/%d/%d", pageNumber, pageSize), &authorities); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
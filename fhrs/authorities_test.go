@@ -0,0 +1,296 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const authorityBody = `{
+  "LocalAuthorityId": 197,
+  "LocalAuthorityIdCode": "760",
+  "Name": "Aberdeen City",
+  "FriendlyName": "aberdeen-city",
+  "Url": "http://www.aberdeencity.gov.uk",
+  "SchemeUrl": "",
+  "Email": "foodhealth@aberdeencity.gov.uk",
+  "RegionName": "Scotland",
+  "FileName": "http://ratings.food.gov.uk/OpenDataFiles/FHRS760en-GB.xml",
+  "FileNameWelsh": null,
+  "EstablishmentCount": 1821,
+  "CreationDate": "2010-08-17T00:00:00",
+  "LastPublishedDate": "2020-01-31T00:37:40.107",
+  "SchemeType": 2,
+  "links": [
+	{
+	  "rel": "self",
+	  "href": "http://api.ratings.food.gov.uk/authorities/197"
+	}
+  ]
+}`
+
+func expectedAuthority() Authority {
+	cd, _ := time.ParseInLocation("2006-01-02T15:04:05", "2010-08-17T00:00:00", ukLocation)
+	lpd, _ := time.ParseInLocation("2006-01-02T15:04:05", "2020-01-31T00:37:40.107", ukLocation)
+
+	return Authority{
+		LocalAuthorityID:     197,
+		LocalAuthorityIDCode: "760",
+		Name:                 "Aberdeen City",
+		FriendlyName:         "aberdeen-city",
+		URL:                  "http://www.aberdeencity.gov.uk",
+		Email:                "foodhealth@aberdeencity.gov.uk",
+		RegionName:           "Scotland",
+		FileName:             "http://ratings.food.gov.uk/OpenDataFiles/FHRS760en-GB.xml",
+		EstablishmentCount:   1821,
+		CreationDate:         Timestamp(cd),
+		LastPublishedDate:    Timestamp(lpd),
+		SchemeType:           2,
+		Links: []Link{
+			{
+				Rel:  "self",
+				Href: "http://api.ratings.food.gov.uk/authorities/197",
+			},
+		},
+	}
+}
+
+func TestAuthoritiesGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "authorities": [` + authorityBody + `],
+	  "meta": {
+		"dataSource": "API",
+		"extractDate": "0001-01-01T00:00:00",
+		"itemCount": 1,
+		"returncode": "OK",
+		"totalCount": 1,
+		"totalPages": 1,
+		"pageSize": 1,
+		"pageNumber": 1
+	  },
+	  "links": [
+		{
+		  "rel": "self",
+		  "href": "http://api.ratings.food.gov.uk/authorities"
+		}
+	  ]
+	}`
+
+	expected := &Authorities{
+		Authorities: []Authority{expectedAuthority()},
+		Meta: Meta{
+			DataSource: "API",
+			ItemCount:  1,
+			Returncode: "OK",
+			TotalCount: 1,
+			TotalPages: 1,
+			PageSize:   1,
+			PageNumber: 1,
+		},
+		Links: []Link{
+			{
+				Rel:  "self",
+				Href: "http://api.ratings.food.gov.uk/authorities",
+			},
+		},
+	}
+
+	router.GET("/Authorities", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.Authorities.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestAuthoritiesGetPage(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Authorities/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "2" {
+			t.Errorf("Expected pageNumber to be 2 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "10" {
+			t.Errorf("Expected pageSize to be 10 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "authorities": [`+authorityBody+`], "meta": { "pageNumber": 2, "pageSize": 10 } }`)
+	})
+
+	actual, err := client.Authorities.GetPage(2, 10)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if actual.Meta.PageNumber != 2 {
+		t.Errorf("Expected page number to be 2 but got %d", actual.Meta.PageNumber)
+	}
+
+	if len(actual.Authorities) != 1 {
+		t.Fatalf("Expected 1 authority but got %d", len(actual.Authorities))
+	}
+
+	if !reflect.DeepEqual(expectedAuthority(), actual.Authorities[0]) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expectedAuthority(), actual.Authorities[0])
+	}
+}
+
+func TestAuthoritiesGetByID(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	idQuery := "197"
+
+	router.GET("/Authorities/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if q := p.ByName("id"); q != idQuery {
+			t.Errorf("Expected ID to be %s but got %s", idQuery, q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, authorityBody)
+	})
+
+	actual, err := client.Authorities.GetByID(idQuery)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := expectedAuthority()
+	if !reflect.DeepEqual(&expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", &expected, actual)
+	}
+}
+
+func TestAuthoritiesBasic(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "authorities": [
+		{
+		  "LocalAuthorityId": 197,
+		  "LocalAuthorityIdCode": "760",
+		  "Name": "Aberdeen City",
+		  "EstablishmentCount": 1821,
+		  "SchemeType": 2,
+		  "links": [
+			{
+			  "rel": "self",
+			  "href": "http://api.ratings.food.gov.uk/authorities/197"
+			}
+		  ]
+		}
+	  ],
+	  "meta": {
+		"dataSource": "API",
+		"extractDate": "0001-01-01T00:00:00",
+		"itemCount": 1,
+		"returncode": "OK",
+		"totalCount": 1,
+		"totalPages": 1,
+		"pageSize": 1,
+		"pageNumber": 1
+	  },
+	  "links": []
+	}`
+
+	expected := &BasicAuthorities{
+		Authorities: []BasicAuthority{
+			{
+				LocalAuthorityID:     197,
+				LocalAuthorityIDCode: "760",
+				Name:                 "Aberdeen City",
+				EstablishmentCount:   1821,
+				SchemeType:           2,
+				Links: []Link{
+					{
+						Rel:  "self",
+						Href: "http://api.ratings.food.gov.uk/authorities/197",
+					},
+				},
+			},
+		},
+		Meta: Meta{
+			DataSource: "API",
+			ItemCount:  1,
+			Returncode: "OK",
+			TotalCount: 1,
+			TotalPages: 1,
+			PageSize:   1,
+			PageNumber: 1,
+		},
+		Links: []Link{},
+	}
+
+	router.GET("/Authorities/basic", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/Authorities/basic/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "1" {
+			t.Errorf("Expected pageNumber to be 1 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "1" {
+			t.Errorf("Expected pageSize to be 1 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.Authorities.Basic()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.Authorities.BasicPage(1, 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
@@ -0,0 +1,133 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of concurrent requests GetByIDs makes
+// when no other value is given.
+const defaultBatchConcurrency = 4
+
+// BatchOptions configures GetByIDs.
+type BatchOptions struct {
+	// Concurrency is the maximum number of requests made at once. It
+	// defaults to 4.
+	Concurrency int
+}
+
+// BatchStatus is the outcome of looking up a single ID in a batch.
+type BatchStatus int
+
+const (
+	BatchFound    BatchStatus = iota // The establishment was returned.
+	BatchNotFound                    // No establishment has the ID.
+	BatchFailed                      // The lookup failed, see BatchResult.Err.
+)
+
+func (s BatchStatus) String() string {
+	return []string{"found", "not found", "failed"}[s]
+}
+
+// BatchResult is the result of looking up a single ID in a batch.
+type BatchResult struct {
+	Status        BatchStatus
+	Establishment *Establishment // Set when Status is BatchFound.
+	Err           error          // Set when Status is BatchFailed.
+}
+
+// GetByIDs returns the establishments with the given FHRSIDs, keyed by ID.
+//
+// Duplicate IDs are only requested once. Each ID gets its own result, so one
+// failure does not stop the rest of the batch. The returned error is only set
+// if ctx is done before every ID has been looked up, in which case the
+// remaining IDs are marked as failed.
+func (s *EstablishmentsService) GetByIDs(ctx context.Context, ids []string, opts *BatchOptions) (map[string]BatchResult, error) {
+	concurrency := defaultBatchConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	seen := make(map[string]bool, len(ids))
+	var unique []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	results := make(map[string]BatchResult, len(unique))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+
+	for w := 0; w < concurrency && w < len(unique); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for id := range jobs {
+				result := s.lookup(ctx, id)
+
+				mu.Lock()
+				results[id] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var err error
+
+dispatch:
+	for _, id := range unique {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		select {
+		case jobs <- id:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	// Lookups in flight when ctx ended fail with its error, even if every ID
+	// had been dispatched.
+	if err == nil && ctx.Err() != nil {
+		for _, result := range results {
+			if result.Status == BatchFailed && errors.Is(result.Err, ctx.Err()) {
+				err = ctx.Err()
+				break
+			}
+		}
+	}
+
+	// Record why the IDs which were never dispatched failed.
+	for _, id := range unique {
+		if _, ok := results[id]; !ok {
+			results[id] = BatchResult{Status: BatchFailed, Err: err}
+		}
+	}
+
+	return results, err
+}
+
+func (s *EstablishmentsService) lookup(ctx context.Context, id string) BatchResult {
+	establishment, err := s.GetByIDContext(ctx, id)
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrEmptyResponse):
+		// An empty body is as good as a 404.
+		return BatchResult{Status: BatchNotFound}
+	case err != nil:
+		return BatchResult{Status: BatchFailed, Err: err}
+	}
+
+	return BatchResult{Status: BatchFound, Establishment: establishment}
+}
@@ -0,0 +1,158 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestGetByIDs(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+
+		mu.Lock()
+		calls[id]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch id {
+		case "0":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{ "Message": "No establishment found with EstablishmentId: 0" }`)
+		case "AAAA":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{ "Message": "The request is invalid" }`)
+		default:
+			io.WriteString(w, fmt.Sprintf(`{ "FHRSID": %s }`, id))
+		}
+	})
+
+	ids := []string{"82940", "0", "AAAA", "82940", "1"}
+	results, err := client.Establishments.GetByIDs(context.Background(), ids, &BatchOptions{Concurrency: 2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 4 {
+		t.Errorf("Expected 4 results but got %d", len(results))
+	}
+
+	if calls["82940"] != 1 {
+		t.Errorf("Expected duplicate IDs to be requested once but got %d calls", calls["82940"])
+	}
+
+	if r := results["82940"]; r.Status != BatchFound || r.Establishment == nil || r.Establishment.FHRSID != 82940 {
+		t.Errorf("Expected 82940 to be found but got %+v", r)
+	}
+
+	if r := results["0"]; r.Status != BatchNotFound || r.Err != nil {
+		t.Errorf("Expected 0 to be not found but got %+v", r)
+	}
+
+	if r := results["AAAA"]; r.Status != BatchFailed || !errors.As(r.Err, &APIError{}) {
+		t.Errorf("Expected AAAA to fail with an APIError but got %+v", r)
+	}
+
+	if r := results["1"]; r.Status != BatchFound {
+		t.Errorf("Expected 1 to be found despite earlier failures but got %+v", r)
+	}
+}
+
+func TestGetByIDs_Canceled(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		io.WriteString(w, `{ "FHRSID": 1 }`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := client.Establishments.GetByIDs(ctx, []string{"1", "2", "3"}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected err to be context.Canceled but got %v", err)
+	}
+
+	for id, r := range results {
+		if r.Status != BatchFailed {
+			t.Errorf("Expected %s to have failed but got %s", id, r.Status)
+		}
+	}
+}
+
+func TestGetByIDs_CanceledInFlight(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started sync.WaitGroup
+	started.Add(2)
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		started.Done()
+		<-r.Context().Done()
+	})
+
+	// Cancel once every ID has been dispatched and is in flight.
+	go func() {
+		started.Wait()
+		cancel()
+	}()
+
+	results, err := client.Establishments.GetByIDs(ctx, []string{"1", "2"}, &BatchOptions{Concurrency: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected err to be context.Canceled but got %v", err)
+	}
+
+	for id, r := range results {
+		if r.Status != BatchFailed {
+			t.Errorf("Expected %s to have failed but got %s", id, r.Status)
+		}
+	}
+}
+
+func TestBatchStatusString(t *testing.T) {
+	cases := []struct {
+		want string
+		have string
+	}{
+		{want: "found", have: BatchFound.String()},
+		{want: "not found", have: BatchNotFound.String()},
+		{want: "failed", have: BatchFailed.String()},
+	}
+
+	for _, c := range cases {
+		if c.have != c.want {
+			t.Errorf("Expected %s but got %s", c.want, c.have)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Package bulk downloads the FSA's open data files, which hold every
+// establishment of each local authority, and decodes them into a single
+// stream of fhrs.Establishment.
+//
+//	d, err := bulk.New(client, "/var/lib/fhrs", &bulk.Options{
+//		OnFile: func(f bulk.File) {
+//			log.Printf("%s %s sha256:%s", f.Authority.Name, f.Path, f.SHA256)
+//		},
+//	})
+//	if err != nil {
+//		// Handle err
+//	}
+//
+//	for res := range d.Establishments(ctx) {
+//		if res.Err != nil {
+//			// Handle err, the rest of the files are still read
+//			continue
+//		}
+//
+//		// Do stuff with res.Establishment
+//	}
+//
+// The list of files comes from the Authorities endpoint of the API. Files are
+// kept in a directory along with a manifest of when each was published and its
+// SHA-256, so a file is only downloaded again once its authority publishes a
+// newer one or the copy on disk no longer matches.
+//
+// An interrupted download is resumed on the next run, but only if the
+// authority has not published since and the server confirms, through
+// If-Range, that the file is the same one.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultWorkers is the number of concurrent downloads when no other value is
+// given.
+const defaultWorkers = 4
+
+// Options configures a Downloader.
+type Options struct {
+	// Workers is the maximum number of files downloaded at once. It
+	// defaults to 4.
+	Workers int
+
+	// Welsh downloads the Welsh language file of authorities which have one.
+	Welsh bool
+
+	// SkipUnchanged leaves files which have not changed since they were last
+	// downloaded out of the stream. By default they are read from disk.
+	SkipUnchanged bool
+
+	// HTTPClient is used to download files. It defaults to an http.Client
+	// without a timeout, as large files can take some time.
+	HTTPClient *http.Client
+
+	// OnFile is called, if set, once each file has been downloaded, found to
+	// be unchanged or failed. It may be called from several goroutines at
+	// once.
+	OnFile func(File)
+}
+
+// File describes a file handled by a Downloader.
+type File struct {
+	Authority fhrs.Authority
+	URL       string
+	Path      string
+	Size      int64
+	SHA256    string // Hex encoded checksum of the file.
+	Unchanged bool   // The file was not downloaded as it has not changed.
+	Resumed   bool   // The download continued from an earlier attempt.
+	Err       error
+}
+
+// Result is an establishment from one of the files, or an error reading a
+// file. An error for one file does not stop the rest from being read.
+type Result struct {
+	Authority     fhrs.Authority
+	Establishment *fhrs.Establishment // Set when Err is nil.
+	Err           error
+}
+
+// Downloader downloads and decodes open data files.
+type Downloader struct {
+	client   *fhrs.Client
+	dir      string
+	opts     Options
+	manifest *manifest
+}
+
+// New returns a Downloader which lists authorities with client and keeps files
+// in dir, creating it if needed.
+func New(client *fhrs.Client, dir string, opts *Options) (*Downloader, error) {
+	if client == nil {
+		return nil, errors.New("Client must not be nil")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	m, err := loadManifest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Reading manifest: %v", err)
+	}
+
+	d := &Downloader{
+		client:   client,
+		dir:      dir,
+		manifest: m,
+	}
+
+	if opts != nil {
+		d.opts = *opts
+	}
+
+	if d.opts.Workers < 1 {
+		d.opts.Workers = defaultWorkers
+	}
+
+	if d.opts.HTTPClient == nil {
+		d.opts.HTTPClient = &http.Client{}
+	}
+
+	return d, nil
+}
+
+// Establishments downloads the file of every authority and sends each
+// establishment in them on the returned channel. The establishments of each
+// file are sent together, in the order the files finish downloading.
+//
+// If the authorities cannot be listed a single Result with the error is sent.
+// The channel is closed once every file has been read or when ctx is done.
+// Callers which stop reading early should cancel ctx so the downloads stop.
+func (d *Downloader) Establishments(ctx context.Context) <-chan Result {
+	out := make(chan Result)
+
+	go func() {
+		defer close(out)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		authorities, err := d.client.Authorities.GetContext(ctx)
+		if err != nil {
+			select {
+			case out <- Result{Err: err}:
+			case <-ctx.Done():
+			}
+
+			return
+		}
+
+		jobs := make(chan fhrs.Authority)
+		files := make(chan File)
+
+		go func() {
+			defer close(jobs)
+
+			for _, a := range authorities.Authorities {
+				select {
+				case jobs <- a:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		var wg sync.WaitGroup
+		for w := 0; w < d.opts.Workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for a := range jobs {
+					f, ok := d.fetch(ctx, a)
+					if !ok {
+						continue
+					}
+
+					select {
+					case files <- f:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(files)
+		}()
+
+		for f := range files {
+			if f.Err == nil && f.Unchanged && d.opts.SkipUnchanged {
+				continue
+			}
+
+			if !d.decode(ctx, f, out) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// fetch downloads the file of a, unless it is unchanged, and reports it to
+// OnFile. It returns false if a has no file.
+func (d *Downloader) fetch(ctx context.Context, a fhrs.Authority) (File, bool) {
+	rawURL := a.FileName
+	if d.opts.Welsh && a.FileNameWelsh != "" {
+		rawURL = a.FileNameWelsh
+	}
+
+	if rawURL == "" {
+		return File{}, false
+	}
+
+	f := File{Authority: a, URL: rawURL}
+
+	name, err := fileName(rawURL)
+	if err != nil {
+		f.Err = err
+		d.report(f)
+		return f, true
+	}
+
+	f.Path = filepath.Join(d.dir, name)
+	f.Err = d.update(ctx, &f, name)
+	d.report(f)
+
+	return f, true
+}
+
+// update brings the file described by f up to date, filling in its details.
+func (d *Downloader) update(ctx context.Context, f *File, name string) error {
+	if entry, ok := d.manifest.get(name); ok && !publishedAfter(f.Authority.LastPublishedDate, entry.LastPublishedDate) {
+		// Only trust the file if it is the one we downloaded.
+		size, sum, err := checksum(f.Path)
+		if err == nil && size == entry.Size && sum == entry.SHA256 {
+			f.Size, f.SHA256, f.Unchanged = size, sum, true
+			return nil
+		}
+	}
+
+	resumed, err := download(ctx, d.opts.HTTPClient, f.URL, f.Path, f.Authority.LastPublishedDate)
+	if err != nil {
+		return err
+	}
+
+	size, sum, err := checksum(f.Path)
+	if err != nil {
+		return err
+	}
+
+	f.Size, f.SHA256, f.Resumed = size, sum, resumed
+
+	return d.manifest.set(name, manifestEntry{
+		URL:               f.URL,
+		LastPublishedDate: f.Authority.LastPublishedDate,
+		Size:              size,
+		SHA256:            sum,
+		Downloaded:        time.Now(),
+	})
+}
+
+func (d *Downloader) report(f File) {
+	if d.opts.OnFile != nil {
+		d.opts.OnFile(f)
+	}
+}
+
+// decode sends the establishments in f, or its error, on out. It returns false
+// if ctx is done.
+func (d *Downloader) decode(ctx context.Context, f File, out chan<- Result) bool {
+	send := func(r Result) bool {
+		select {
+		case out <- r:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if f.Err != nil {
+		return send(Result{Authority: f.Authority, Err: f.Err})
+	}
+
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return send(Result{Authority: f.Authority, Err: err})
+	}
+	defer file.Close()
+
+	dec := fhrs.NewXMLDecoder(file)
+	for dec.Next() {
+		if !send(Result{Authority: f.Authority, Establishment: dec.Establishment()}) {
+			return false
+		}
+	}
+
+	if err := dec.Err(); err != nil {
+		return send(Result{Authority: f.Authority, Err: fmt.Errorf("Reading %s: %v", f.Path, err)})
+	}
+
+	return true
+}
+
+// fileName returns the name to save the file at rawURL as. As the URL comes
+// from the API, names which would escape the directory or clash with the
+// Downloader's own files are rejected.
+func fileName(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	name := path.Base(u.Path)
+	switch {
+	case name == "." || name == "/":
+		return "", fmt.Errorf("No file name in %s", rawURL)
+	case name == ".." || strings.ContainsAny(name, `/\`),
+		name == manifestName || name == manifestName+tmpExt,
+		strings.HasSuffix(name, partExt) || strings.HasSuffix(name, partExt+partInfoExt):
+		return "", fmt.Errorf("Invalid file name %q in %s", name, rawURL)
+	}
+
+	return name, nil
+}
+
+// publishedAfter reports whether a was published after b. A missing date is
+// treated as always newer, as the file can't be known to be unchanged.
+func publishedAfter(a, b fhrs.Timestamp) bool {
+	if a.IsZero() || b.IsZero() {
+		return true
+	}
+
+	return a.Time().After(b.Time())
+}
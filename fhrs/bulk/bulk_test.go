@@ -0,0 +1,404 @@
+package bulk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"github.com/dcrichards/go-fhrs/fhrs/fhrstest"
+	"github.com/julienschmidt/httprouter"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// openDataFile returns an open data file holding establishments with the
+// given FHRSIDs.
+func openDataFile(ids ...string) string {
+	var details string
+	for _, id := range ids {
+		details += "<EstablishmentDetail><FHRSID>" + id + "</FHRSID><BusinessName>Establishment " + id +
+			"</BusinessName><RatingValue>5</RatingValue><RatingKey>fhrs_5_en-GB</RatingKey></EstablishmentDetail>"
+	}
+
+	return `<?xml version="1.0" encoding="utf-8"?><FHRSEstablishment><Header><ItemCount>` +
+		"0</ItemCount></Header><EstablishmentCollection>" + details + "</EstablishmentCollection></FHRSEstablishment>"
+}
+
+// fileServer serves open data files, counting requests for each.
+type fileServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	files    map[string]string
+	requests map[string]int
+	ranges   []string
+	ifRanges []string
+}
+
+func newFileServer(files map[string]string) *fileServer {
+	s := &fileServer{files: files, requests: map[string]int{}}
+
+	router := httprouter.New()
+	router.GET("/OpenDataFiles/:name", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		name := p.ByName("name")
+
+		s.mu.Lock()
+		s.requests[name]++
+		if rng := r.Header.Get("Range"); rng != "" {
+			s.ranges = append(s.ranges, rng)
+			s.ifRanges = append(s.ifRanges, r.Header.Get("If-Range"))
+		}
+		content, ok := s.files[name]
+		s.mu.Unlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Header().Set("ETag", etag(content))
+		http.ServeContent(w, r, name, time.Time{}, strings.NewReader(content))
+	})
+
+	s.Server = httptest.NewServer(router)
+	return s
+}
+
+// etag returns a strong ETag for content.
+func etag(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func (s *fileServer) count(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[name]
+}
+
+type testEnv struct {
+	fake  *fhrstest.Server
+	files *fileServer
+	dir   string
+}
+
+func getTestEnv(t *testing.T) (*testEnv, func()) {
+	files := newFileServer(map[string]string{
+		"FHRS876en-GB.xml": openDataFile("1", "2"),
+		"FHRS875en-GB.xml": openDataFile("3"),
+		"FHRS875cy-GB.xml": openDataFile("3"),
+	})
+
+	published, _ := fhrs.ParseTimestamp("2020-01-31T00:37:40")
+
+	fake := fhrstest.NewServer(fhrstest.Data{
+		Authorities: []fhrs.Authority{
+			{
+				LocalAuthorityID:  1,
+				Name:              "Portsmouth",
+				FileName:          files.URL + "/OpenDataFiles/FHRS876en-GB.xml",
+				LastPublishedDate: published,
+			},
+			{
+				LocalAuthorityID:  2,
+				Name:              "Brighton and Hove",
+				FileName:          files.URL + "/OpenDataFiles/FHRS875en-GB.xml",
+				FileNameWelsh:     files.URL + "/OpenDataFiles/FHRS875cy-GB.xml",
+				LastPublishedDate: published,
+			},
+			{
+				LocalAuthorityID: 3,
+				Name:             "No File",
+			},
+		},
+	})
+
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testEnv{fake: fake, files: files, dir: dir}, func() {
+		fake.Close()
+		files.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func (env *testEnv) downloader(t *testing.T, opts *Options) *Downloader {
+	client, err := env.fake.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New(client, env.dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+// collect returns the sorted FHRSIDs and errors from a run.
+func collect(d *Downloader) ([]int, []error) {
+	var ids []int
+	var errs []error
+
+	for res := range d.Establishments(context.Background()) {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+			continue
+		}
+
+		ids = append(ids, res.Establishment.FHRSID)
+	}
+
+	sort.Ints(ids)
+	return ids, errs
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestEstablishments(t *testing.T) {
+	env, cleanup := getTestEnv(t)
+	defer cleanup()
+
+	var mu sync.Mutex
+	var files []File
+
+	d := env.downloader(t, &Options{
+		Workers: 2,
+		OnFile: func(f File) {
+			mu.Lock()
+			files = append(files, f)
+			mu.Unlock()
+		},
+	})
+
+	ids, errs := collect(d)
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	if !equalInts([]int{1, 2, 3}, ids) {
+		t.Errorf("Expected establishments 1, 2 and 3 but got %v", ids)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files but got %d", len(files))
+	}
+
+	for _, f := range files {
+		if f.Unchanged || f.Resumed || f.Err != nil {
+			t.Errorf("Unexpected file %+v", f)
+		}
+
+		content := env.files.files[filepath.Base(f.Path)]
+		sum := sha256.Sum256([]byte(content))
+		if f.SHA256 != hex.EncodeToString(sum[:]) || f.Size != int64(len(content)) {
+			t.Errorf("Unexpected checksum or size for %s", f.Path)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(env.dir, manifestName)); err != nil {
+		t.Errorf("Expected a manifest: %v", err)
+	}
+}
+
+func TestEstablishments_Unchanged(t *testing.T) {
+	env, cleanup := getTestEnv(t)
+	defer cleanup()
+
+	if _, errs := collect(env.downloader(t, nil)); len(errs) != 0 {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	// Unchanged files are read from disk.
+	var unchanged int
+	d := env.downloader(t, &Options{OnFile: func(f File) {
+		if f.Unchanged {
+			unchanged++
+		}
+	}, Workers: 1})
+
+	ids, errs := collect(d)
+	if len(errs) != 0 || !equalInts([]int{1, 2, 3}, ids) {
+		t.Errorf("Expected establishments 1, 2 and 3 but got %v, %v", ids, errs)
+	}
+
+	if unchanged != 2 {
+		t.Errorf("Expected 2 unchanged files but got %d", unchanged)
+	}
+
+	if n := env.files.count("FHRS876en-GB.xml"); n != 1 {
+		t.Errorf("Expected the file to be downloaded once but got %d", n)
+	}
+
+	// Or left out.
+	ids, _ = collect(env.downloader(t, &Options{SkipUnchanged: true}))
+	if len(ids) != 0 {
+		t.Errorf("Expected no establishments but got %v", ids)
+	}
+
+	// A newer file is downloaded again.
+	env.fake.Update(func(data *fhrstest.Data) {
+		data.Authorities[0].LastPublishedDate = fhrs.Timestamp(data.Authorities[0].LastPublishedDate.Time().Add(time.Hour))
+	})
+
+	ids, _ = collect(env.downloader(t, &Options{SkipUnchanged: true}))
+	if !equalInts([]int{1, 2}, ids) {
+		t.Errorf("Expected establishments 1 and 2 but got %v", ids)
+	}
+
+	if n := env.files.count("FHRS876en-GB.xml"); n != 2 {
+		t.Errorf("Expected the file to be downloaded twice but got %d", n)
+	}
+
+	// A file changed on disk is downloaded again.
+	if err := ioutil.WriteFile(filepath.Join(env.dir, "FHRS875en-GB.xml"), []byte("corrupt"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, errs = collect(env.downloader(t, nil))
+	if len(errs) != 0 || !equalInts([]int{1, 2, 3}, ids) {
+		t.Errorf("Expected establishments 1, 2 and 3 but got %v, %v", ids, errs)
+	}
+
+	if n := env.files.count("FHRS875en-GB.xml"); n != 2 {
+		t.Errorf("Expected the corrupt file to be downloaded again but got %d requests", n)
+	}
+}
+
+func TestEstablishments_Welsh(t *testing.T) {
+	env, cleanup := getTestEnv(t)
+	defer cleanup()
+
+	if _, errs := collect(env.downloader(t, &Options{Welsh: true})); len(errs) != 0 {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	if env.files.count("FHRS875cy-GB.xml") != 1 || env.files.count("FHRS875en-GB.xml") != 0 {
+		t.Error("Expected the Welsh file to be downloaded instead of the English one")
+	}
+
+	if env.files.count("FHRS876en-GB.xml") != 1 {
+		t.Error("Expected the English file to be used where there is no Welsh one")
+	}
+}
+
+func TestEstablishments_FileErrors(t *testing.T) {
+	env, cleanup := getTestEnv(t)
+	defer cleanup()
+
+	delete(env.files.files, "FHRS876en-GB.xml")
+	env.files.files["FHRS875en-GB.xml"] = "<html></html>"
+
+	ids, errs := collect(env.downloader(t, nil))
+	if len(ids) != 0 {
+		t.Errorf("Expected no establishments but got %v", ids)
+	}
+
+	if len(errs) != 2 {
+		t.Errorf("Expected an error for each file but got %v", errs)
+	}
+}
+
+func TestEstablishments_AuthoritiesError(t *testing.T) {
+	faults := fhrstest.NewFaultServer(nil)
+	defer faults.Close()
+
+	client, err := faults.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d, err := New(client, dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		fault fhrstest.Fault
+	}{
+		{"ServerError", fhrstest.ServerError()},
+		{"Empty", fhrstest.Status(http.StatusOK, fhrs.ContentTypeJSON, "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults.Always("/Authorities", tt.fault)
+
+			if _, errs := collect(d); len(errs) != 1 {
+				t.Errorf("Expected a single error but got %v", errs)
+			}
+		})
+	}
+}
+
+func TestEstablishments_Cancel(t *testing.T) {
+	env, cleanup := getTestEnv(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := env.downloader(t, nil).Establishments(ctx)
+
+	<-results
+	cancel()
+
+	// The channel must be closed without reading everything.
+	for range results {
+	}
+}
+
+func TestFileName(t *testing.T) {
+	name, err := fileName("http://ratings.food.gov.uk/OpenDataFiles/FHRS760en-GB.xml")
+	if err != nil || name != "FHRS760en-GB.xml" {
+		t.Errorf("Expected FHRS760en-GB.xml but got %q, %v", name, err)
+	}
+
+	invalid := []string{
+		"http://ratings.food.gov.uk/",
+		"http://ratings.food.gov.uk/OpenDataFiles/..",
+		"http://ratings.food.gov.uk/OpenDataFiles/%2E%2E",
+		"http://ratings.food.gov.uk/OpenDataFiles/..%5C..%5Cevil.xml",
+		"http://ratings.food.gov.uk/OpenDataFiles/manifest.json",
+		"http://ratings.food.gov.uk/OpenDataFiles/manifest.json.tmp",
+		"http://ratings.food.gov.uk/OpenDataFiles/FHRS760en-GB.xml.part",
+		"http://ratings.food.gov.uk/OpenDataFiles/FHRS760en-GB.xml.part.json",
+	}
+
+	for _, rawURL := range invalid {
+		if name, err := fileName(rawURL); err == nil {
+			t.Errorf("Expected an error for %s but got %q", rawURL, name)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package bulk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// partExt is added to the name of a file while it is downloaded.
+	partExt = ".part"
+
+	// partInfoExt is added to the name of a part file for the file
+	// describing it.
+	partInfoExt = ".json"
+)
+
+// partInfo records what a part file was started from, so that a download is
+// only resumed if the file being downloaded is still the same one.
+type partInfo struct {
+	URL               string         `json:"url"`
+	LastPublishedDate fhrs.Timestamp `json:"lastPublishedDate"`
+	Validator         string         `json:"validator"` // ETag or Last-Modified of the file.
+}
+
+// download fetches rawURL, published at published, to path. The file is
+// written to path+".part" first. If that already exists from an earlier
+// attempt at the same file, the download continues from where it stopped
+// provided the server supports ranges and the file has not changed since. It
+// reports whether the download was resumed.
+//
+// The finished file is checked against the length the server declared and,
+// if it sends one, its SHA-256 Digest header.
+func download(ctx context.Context, httpClient *http.Client, rawURL, path string, published fhrs.Timestamp) (bool, error) {
+	part := path + partExt
+	offset, validator := resumeFrom(part, rawURL, published)
+
+	res, err := get(ctx, httpClient, rawURL, offset, validator)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	length := res.ContentLength
+
+	switch {
+	case res.StatusCode == http.StatusPartialContent && offset > 0:
+		start, total, err := parseContentRange(res.Header.Get("Content-Range"))
+		if err != nil || start != offset {
+			removePart(part)
+			return false, fmt.Errorf("Downloading %s returned range %q, expected one starting at %d", rawURL, res.Header.Get("Content-Range"), offset)
+		}
+
+		flags |= os.O_APPEND
+		length = total
+	case res.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The part is probably complete, but as we can't tell, start again.
+		res.Body.Close()
+		if err := removePart(part); err != nil {
+			return false, err
+		}
+
+		return download(ctx, httpClient, rawURL, path, published)
+	case res.StatusCode == http.StatusOK:
+		// The file has changed or the server ignored the range, so start
+		// from the beginning.
+		flags |= os.O_TRUNC
+		offset = 0
+
+		info := partInfo{URL: rawURL, LastPublishedDate: published, Validator: responseValidator(res)}
+		if err := writePartInfo(part, info); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("Downloading %s returned status %d", rawURL, res.StatusCode)
+	}
+
+	f, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := io.Copy(f, res.Body); err != nil {
+		f.Close()
+		return false, fmt.Errorf("Downloading %s: %v", rawURL, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return false, err
+	}
+
+	if err := verify(part, length, res.Header.Get("Digest")); err != nil {
+		removePart(part)
+		return false, fmt.Errorf("Downloading %s: %v", rawURL, err)
+	}
+
+	if err := os.Rename(part, path); err != nil {
+		return false, err
+	}
+
+	os.Remove(part + partInfoExt)
+	return offset > 0, nil
+}
+
+// resumeFrom returns the offset and validator to resume the download of
+// rawURL into part from. A part which was started from another URL or
+// publication, or which can't be checked with the server, is removed.
+func resumeFrom(part, rawURL string, published fhrs.Timestamp) (int64, string) {
+	fi, err := os.Stat(part)
+	if err != nil {
+		os.Remove(part + partInfoExt)
+		return 0, ""
+	}
+
+	info, err := readPartInfo(part)
+	if err != nil || info.URL != rawURL || info.Validator == "" ||
+		!info.LastPublishedDate.Time().Equal(published.Time()) {
+		removePart(part)
+		return 0, ""
+	}
+
+	return fi.Size(), info.Validator
+}
+
+// get requests rawURL, starting from offset if it is not zero. The range is
+// only honoured if the file still matches validator.
+func get(ctx context.Context, httpClient *http.Client, rawURL string, offset int64, validator string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		req.Header.Set("If-Range", validator)
+	}
+
+	return httpClient.Do(req)
+}
+
+// responseValidator returns the value to send as If-Range when resuming the
+// file in res. Weak ETags can't be used for ranges.
+func responseValidator(res *http.Response) string {
+	if etag := res.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+
+	return res.Header.Get("Last-Modified")
+}
+
+// parseContentRange returns the first byte and total length of a
+// Content-Range header such as "bytes 100-199/200". The total is -1 if it is
+// unknown.
+func parseContentRange(s string) (start, total int64, err error) {
+	invalid := fmt.Errorf("Invalid Content-Range %q", s)
+
+	if !strings.HasPrefix(s, "bytes ") {
+		return 0, 0, invalid
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(s, "bytes "), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, invalid
+	}
+
+	bounds := strings.SplitN(parts[0], "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, invalid
+	}
+
+	if start, err = strconv.ParseInt(bounds[0], 10, 64); err != nil {
+		return 0, 0, invalid
+	}
+
+	if parts[1] == "*" {
+		return start, -1, nil
+	}
+
+	if total, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return 0, 0, invalid
+	}
+
+	return start, total, nil
+}
+
+// verify checks the file at path against the length the server declared, if
+// it is not -1, and a Digest header holding its SHA-256, if there is one.
+func verify(path string, length int64, digest string) error {
+	size, sum, err := checksum(path)
+	if err != nil {
+		return err
+	}
+
+	if length >= 0 && size != length {
+		return fmt.Errorf("Got %d bytes, expected %d", size, length)
+	}
+
+	for _, d := range strings.Split(digest, ",") {
+		parts := strings.SplitN(strings.TrimSpace(d), "=", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "sha-256") {
+			continue
+		}
+
+		want, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return fmt.Errorf("Invalid Digest %q", d)
+		}
+
+		if hex.EncodeToString(want) != sum {
+			return fmt.Errorf("Checksum sha256:%s does not match Digest %q", sum, d)
+		}
+	}
+
+	return nil
+}
+
+func readPartInfo(part string) (partInfo, error) {
+	var info partInfo
+
+	b, err := ioutil.ReadFile(part + partInfoExt)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(b, &info)
+	return info, err
+}
+
+func writePartInfo(part string, info partInfo) error {
+	b, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(part+partInfoExt, b, 0644)
+}
+
+// removePart removes a part file and its description.
+func removePart(part string) error {
+	os.Remove(part + partInfoExt)
+
+	if err := os.Remove(part); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// checksum returns the size and hex encoded SHA-256 of the file at path.
+func checksum(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,327 @@
+package bulk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const fileURL = "/OpenDataFiles/FHRS1.xml"
+
+var published, _ = fhrs.ParseTimestamp("2020-01-31T00:37:40")
+
+func getDownloadEnv(t *testing.T, content string) (*fileServer, string, func()) {
+	files := newFileServer(map[string]string{"FHRS1.xml": content})
+
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files, filepath.Join(dir, "FHRS1.xml"), func() {
+		files.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// writePart leaves a part file as an interrupted download would.
+func writePart(t *testing.T, path, content string, info partInfo) {
+	if err := ioutil.WriteFile(path+partExt, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writePartInfo(path+partExt, info); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectFile(t *testing.T, path, content string) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != content {
+		t.Errorf("Expected the whole file but got:\n%s", b)
+	}
+
+	for _, p := range []string{path + partExt, path + partExt + partInfoExt} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", p)
+		}
+	}
+}
+
+func TestDownload(t *testing.T) {
+	content := openDataFile("1", "2", "3")
+	files, path, cleanup := getDownloadEnv(t, content)
+	defer cleanup()
+
+	resumed, err := download(context.Background(), http.DefaultClient, files.URL+fileURL, path, published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resumed {
+		t.Error("Expected the download not to be resumed")
+	}
+
+	expectFile(t, path, content)
+}
+
+func TestDownload_Resume(t *testing.T) {
+	content := openDataFile("1", "2", "3")
+	files, path, cleanup := getDownloadEnv(t, content)
+	defer cleanup()
+
+	half := len(content) / 2
+	writePart(t, path, content[:half], partInfo{URL: files.URL + fileURL, LastPublishedDate: published, Validator: etag(content)})
+
+	resumed, err := download(context.Background(), http.DefaultClient, files.URL+fileURL, path, published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resumed {
+		t.Error("Expected the download to be resumed")
+	}
+
+	if len(files.ranges) != 1 || files.ranges[0] != "bytes="+strconv.Itoa(half)+"-" {
+		t.Errorf("Unexpected ranges %v", files.ranges)
+	}
+
+	if len(files.ifRanges) != 1 || files.ifRanges[0] != etag(content) {
+		t.Errorf("Expected If-Range %s but got %v", etag(content), files.ifRanges)
+	}
+
+	expectFile(t, path, content)
+}
+
+func TestDownload_ResumeDiscarded(t *testing.T) {
+	content := openDataFile("1", "2", "3")
+	stale := openDataFile("4", "5", "6")[:len(content)/2]
+
+	newer := fhrs.Timestamp(published.Time().Add(time.Hour))
+
+	tests := []struct {
+		name string
+		info *partInfo
+	}{
+		{"NoInfo", nil},
+		{"NewerPublication", &partInfo{URL: "", LastPublishedDate: newer, Validator: etag(content)}},
+		{"OtherURL", &partInfo{URL: "http://example.com/FHRS1.xml", LastPublishedDate: published, Validator: etag(content)}},
+		{"NoValidator", &partInfo{URL: "", LastPublishedDate: published}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, path, cleanup := getDownloadEnv(t, content)
+			defer cleanup()
+
+			if tt.info == nil {
+				if err := ioutil.WriteFile(path+partExt, []byte(stale), 0644); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				info := *tt.info
+				if info.URL == "" {
+					info.URL = files.URL + fileURL
+				}
+
+				writePart(t, path, stale, info)
+			}
+
+			resumed, err := download(context.Background(), http.DefaultClient, files.URL+fileURL, path, published)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resumed || len(files.ranges) != 0 {
+				t.Errorf("Expected the stale part to be discarded but got ranges %v", files.ranges)
+			}
+
+			expectFile(t, path, content)
+		})
+	}
+}
+
+func TestDownload_FileChanged(t *testing.T) {
+	content := openDataFile("1", "2", "3")
+	files, path, cleanup := getDownloadEnv(t, content)
+	defer cleanup()
+
+	// The part was started from a different file at the same URL, so the
+	// server sends the whole of the new one.
+	stale := openDataFile("4", "5", "6")
+	writePart(t, path, stale[:len(stale)/2], partInfo{URL: files.URL + fileURL, LastPublishedDate: published, Validator: etag(stale)})
+
+	resumed, err := download(context.Background(), http.DefaultClient, files.URL+fileURL, path, published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resumed {
+		t.Error("Expected the download to start again")
+	}
+
+	expectFile(t, path, content)
+}
+
+func TestDownload_RangeIgnored(t *testing.T) {
+	content := openDataFile("1")
+
+	// A server which ignores ranges sends the whole file.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "FHRS1.xml")
+	writePart(t, path, "stale partial content", partInfo{URL: server.URL + "/FHRS1.xml", LastPublishedDate: published, Validator: `"v1"`})
+
+	resumed, err := download(context.Background(), http.DefaultClient, server.URL+"/FHRS1.xml", path, published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resumed {
+		t.Error("Expected the download to start again")
+	}
+
+	expectFile(t, path, content)
+}
+
+func TestDownload_WrongRange(t *testing.T) {
+	content := openDataFile("1")
+
+	// A server which sends the wrong part of the file.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 0-9/"+strconv.Itoa(len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(content[:10]))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "FHRS1.xml")
+	writePart(t, path, content[:20], partInfo{URL: server.URL + "/FHRS1.xml", LastPublishedDate: published, Validator: `"v1"`})
+
+	if _, err := download(context.Background(), http.DefaultClient, server.URL+"/FHRS1.xml", path, published); err == nil {
+		t.Error("Expected an error for the wrong range")
+	}
+
+	if _, err := os.Stat(path + partExt); !os.IsNotExist(err) {
+		t.Error("Expected the part file to be removed")
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected no file")
+	}
+}
+
+func TestDownload_Digest(t *testing.T) {
+	content := openDataFile("1")
+	sum := sha256.Sum256([]byte(content))
+
+	tests := []struct {
+		name   string
+		digest string
+		valid  bool
+	}{
+		{"Match", "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:]), true},
+		{"OtherAlgorithm", "md5=HUXZLQLMuI/KZ5KDcJPcOA==", true},
+		{"Mismatch", "SHA-256=" + base64.StdEncoding.EncodeToString(make([]byte, 32)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Digest", tt.digest)
+				w.Write([]byte(content))
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "bulk")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "FHRS1.xml")
+			_, err = download(context.Background(), http.DefaultClient, server.URL+"/FHRS1.xml", path, published)
+
+			if tt.valid {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expectFile(t, path, content)
+				return
+			}
+
+			if err == nil {
+				t.Fatal("Expected an error for a mismatched digest")
+			}
+
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Error("Expected no file")
+			}
+		})
+	}
+}
+
+func TestDownload_Error(t *testing.T) {
+	files, path, cleanup := getDownloadEnv(t, "")
+	defer cleanup()
+
+	if _, err := download(context.Background(), http.DefaultClient, files.URL+"/OpenDataFiles/missing.xml", path, published); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header string
+		start  int64
+		total  int64
+		valid  bool
+	}{
+		{"bytes 100-199/200", 100, 200, true},
+		{"bytes 0-9/*", 0, -1, true},
+		{"bytes */200", 0, 0, false},
+		{"items 0-9/10", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, total, err := parseContentRange(tt.header)
+		if tt.valid != (err == nil) {
+			t.Errorf("Unexpected error for %q: %v", tt.header, err)
+			continue
+		}
+
+		if tt.valid && (start != tt.start || total != tt.total) {
+			t.Errorf("Expected %d, %d for %q but got %d, %d", tt.start, tt.total, tt.header, start, total)
+		}
+	}
+}
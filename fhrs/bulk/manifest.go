@@ -0,0 +1,90 @@
+package bulk
+
+import (
+	"encoding/json"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// manifestName is the name of the file recording what has been
+	// downloaded.
+	manifestName = "manifest.json"
+
+	// tmpExt is added to the name of the manifest while it is saved.
+	tmpExt = ".tmp"
+)
+
+// manifestEntry records a downloaded file.
+type manifestEntry struct {
+	URL               string         `json:"url"`
+	LastPublishedDate fhrs.Timestamp `json:"lastPublishedDate"`
+	Size              int64          `json:"size"`
+	SHA256            string         `json:"sha256"`
+	Downloaded        time.Time      `json:"downloaded"`
+}
+
+// manifest records the files in a directory, keyed by file name. It is saved
+// after every change so an interrupted run loses nothing.
+type manifest struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]manifestEntry
+}
+
+// loadManifest reads the manifest in dir, returning an empty one if there is
+// none yet.
+func loadManifest(dir string) (*manifest, error) {
+	m := &manifest{
+		path:    filepath.Join(dir, manifestName),
+		entries: map[string]manifestEntry{},
+	}
+
+	b, err := ioutil.ReadFile(m.path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &m.entries); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *manifest) get(name string) (manifestEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[name]
+	return e, ok
+}
+
+// set records the entry for name and saves the manifest.
+func (m *manifest) set(name string, e manifestEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[name] = e
+
+	b, err := json.MarshalIndent(m.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write then rename so a crash can't leave a half written manifest.
+	tmp := m.path + tmpExt
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, m.path)
+}
@@ -0,0 +1,68 @@
+package bulk
+
+import (
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, err := loadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := m.get("FHRS876en-GB.xml"); ok {
+		t.Error("Expected an empty manifest")
+	}
+
+	published, _ := fhrs.ParseTimestamp("2020-01-31T00:37:40")
+	entry := manifestEntry{
+		URL:               "http://ratings.food.gov.uk/OpenDataFiles/FHRS876en-GB.xml",
+		LastPublishedDate: published,
+		Size:              1024,
+		SHA256:            "abc",
+	}
+
+	if err := m.set("FHRS876en-GB.xml", entry); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = loadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := m.get("FHRS876en-GB.xml")
+	if !ok {
+		t.Fatal("Expected the entry to be saved")
+	}
+
+	if got.URL != entry.URL || got.Size != entry.Size || got.SHA256 != entry.SHA256 || !got.LastPublishedDate.Time().Equal(published.Time()) {
+		t.Errorf("Expected %+v but got %+v", entry, got)
+	}
+}
+
+func TestManifest_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, manifestName), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadManifest(dir); err == nil {
+		t.Error("Expected an error for an invalid manifest")
+	}
+}
@@ -0,0 +1,113 @@
+package fhrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// BusinessTypesService encapsulates the BusinessTypes methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#BusinessTypes
+type BusinessTypesService service
+
+// BusinessTypes is a list of business types.
+type BusinessTypes struct {
+	BusinessTypes []BusinessType `json:"businessTypes"`
+	Meta          Meta           `json:"meta"`
+	Links         []Link         `json:"links"`
+}
+
+// BusinessType is a category of establishment.
+//
+// BusinessTypeID is the value expected by SearchParams.BusinessTypeID. The
+// basic endpoints return the same fields without Links.
+type BusinessType struct {
+	BusinessTypeID   int    `json:"BusinessTypeId"`
+	BusinessTypeName string `json:"BusinessTypeName"`
+	Links            []Link `json:"links"`
+}
+
+// Get returns the details of all business types.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-BusinessTypes
+func (s *BusinessTypesService) Get() (*BusinessTypes, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *BusinessTypesService) GetContext(ctx context.Context) (*BusinessTypes, error) {
+	var businessTypes *BusinessTypes
+	if err := s.client.get(ctx, "BusinessTypes", &businessTypes); err != nil {
+		return nil, err
+	}
+
+	return businessTypes, nil
+}
+
+// GetPage returns a single page of business types.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-BusinessTypes-pageNumber-pageSize
+func (s *BusinessTypesService) GetPage(pageNumber, pageSize int) (*BusinessTypes, error) {
+	return s.GetPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// GetPageContext is like GetPage but uses ctx for the request.
+func (s *BusinessTypesService) GetPageContext(ctx context.Context, pageNumber, pageSize int) (*BusinessTypes, error) {
+	var businessTypes *BusinessTypes
+	if err := s.client.get(ctx, fmt.Sprintf("BusinessTypes/%d/%d", pageNumber, pageSize), &businessTypes); err != nil {
+		return nil, err
+	}
+
+	return businessTypes, nil
+}
+
+// GetByID returns the business type with the given ID.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-BusinessTypes-id
+func (s *BusinessTypesService) GetByID(id string) (*BusinessType, error) {
+	return s.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but uses ctx for the request.
+func (s *BusinessTypesService) GetByIDContext(ctx context.Context, id string) (*BusinessType, error) {
+	var businessType *BusinessType
+	if err := s.client.get(ctx, fmt.Sprintf("BusinessTypes/%s", id), &businessType); err != nil {
+		return nil, err
+	}
+
+	return businessType, nil
+}
+
+// Basic returns all business types in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-BusinessTypes-basic
+func (s *BusinessTypesService) Basic() (*BusinessTypes, error) {
+	return s.BasicContext(context.Background())
+}
+
+// BasicContext is like Basic but uses ctx for the request.
+func (s *BusinessTypesService) BasicContext(ctx context.Context) (*BusinessTypes, error) {
+	var businessTypes *BusinessTypes
+	if err := s.client.get(ctx, "BusinessTypes/basic", &businessTypes); err != nil {
+		return nil, err
+	}
+
+	return businessTypes, nil
+}
+
+// BasicPage returns a single page of business types in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-BusinessTypes-basic-pageNumber-pageSize
+func (s *BusinessTypesService) BasicPage(pageNumber, pageSize int) (*BusinessTypes, error) {
+	return s.BasicPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// BasicPageContext is like BasicPage but uses ctx for the request.
+func (s *BusinessTypesService) BasicPageContext(ctx context.Context, pageNumber, pageSize int) (*BusinessTypes, error) {
+	var businessTypes *BusinessTypes
+	if err := s.client.get(ctx, fmt.Sprintf("BusinessTypes/basic/%d/%d", pageNumber, pageSize), &businessTypes); err != nil {
+		return nil, err
+	}
+
+	return businessTypes, nil
+}
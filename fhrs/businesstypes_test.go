@@ -0,0 +1,217 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBusinessTypesGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "businessTypes": [
+		{
+		  "BusinessTypeId": 7844,
+		  "BusinessTypeName": "Distributors/Transporters",
+		  "links": [
+			{
+			  "rel": "self",
+			  "href": "http://api.ratings.food.gov.uk/businesstypes/7844"
+			}
+		  ]
+		}
+	  ],
+	  "meta": {
+		"dataSource": "API",
+		"extractDate": "0001-01-01T00:00:00",
+		"itemCount": 1,
+		"returncode": "OK",
+		"totalCount": 1,
+		"totalPages": 1,
+		"pageSize": 1,
+		"pageNumber": 1
+	  },
+	  "links": [
+		{
+		  "rel": "self",
+		  "href": "http://api.ratings.food.gov.uk/businesstypes"
+		}
+	  ]
+	}`
+
+	expected := &BusinessTypes{
+		BusinessTypes: []BusinessType{
+			{
+				BusinessTypeID:   7844,
+				BusinessTypeName: "Distributors/Transporters",
+				Links: []Link{
+					{
+						Rel:  "self",
+						Href: "http://api.ratings.food.gov.uk/businesstypes/7844",
+					},
+				},
+			},
+		},
+		Meta: Meta{
+			DataSource: "API",
+			ItemCount:  1,
+			Returncode: "OK",
+			TotalCount: 1,
+			TotalPages: 1,
+			PageSize:   1,
+			PageNumber: 1,
+		},
+		Links: []Link{
+			{
+				Rel:  "self",
+				Href: "http://api.ratings.food.gov.uk/businesstypes",
+			},
+		},
+	}
+
+	router.GET("/BusinessTypes", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/BusinessTypes/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "1" {
+			t.Errorf("Expected pageNumber to be 1 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "1" {
+			t.Errorf("Expected pageSize to be 1 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.BusinessTypes.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.BusinessTypes.GetPage(1, 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestBusinessTypesGetByID(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	idQuery := "1"
+	body := `{ "BusinessTypeId": 1, "BusinessTypeName": "Restaurant/Cafe/Canteen", "links": [] }`
+
+	router.GET("/BusinessTypes/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if q := p.ByName("id"); q != idQuery {
+			t.Errorf("Expected ID to be %s but got %s", idQuery, q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	expected := &BusinessType{
+		BusinessTypeID:   1,
+		BusinessTypeName: "Restaurant/Cafe/Canteen",
+		Links:            []Link{},
+	}
+
+	actual, err := client.BusinessTypes.GetByID(idQuery)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestBusinessTypesBasic(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "businessTypes": [
+		{ "BusinessTypeId": -1, "BusinessTypeName": "All" },
+		{ "BusinessTypeId": 1, "BusinessTypeName": "Restaurant/Cafe/Canteen" }
+	  ],
+	  "meta": { "itemCount": 2 },
+	  "links": []
+	}`
+
+	expected := &BusinessTypes{
+		BusinessTypes: []BusinessType{
+			{BusinessTypeID: -1, BusinessTypeName: "All"},
+			{BusinessTypeID: 1, BusinessTypeName: "Restaurant/Cafe/Canteen"},
+		},
+		Meta:  Meta{ItemCount: 2},
+		Links: []Link{},
+	}
+
+	router.GET("/BusinessTypes/basic", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/BusinessTypes/basic/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "3" {
+			t.Errorf("Expected pageNumber to be 3 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "2" {
+			t.Errorf("Expected pageSize to be 2 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.BusinessTypes.Basic()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.BusinessTypes.BasicPage(3, 2)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
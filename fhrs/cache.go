@@ -0,0 +1,257 @@
+package fhrs
+
+import (
+	"container/list"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores API responses so that repeated requests can skip the network.
+// Implementations must be safe for concurrent use.
+//
+// Entries are kept after they expire so that they can be revalidated with
+// ETag or Last-Modified, if the API sent them. An expired entry with neither
+// can't be used, so Get should drop it and report a miss.
+type Cache interface {
+	// Get returns the entry for key, if there is one.
+	Get(key string) (*CacheEntry, bool)
+	// Set stores the entry for key, replacing any existing entry.
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry for key.
+	Delete(key string)
+	// Stats returns the number of hits, misses and evictions so far.
+	Stats() CacheStats
+}
+
+// CacheEntry is a cached response body along with the details needed to
+// revalidate it.
+type CacheEntry struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Expires      time.Time `json:"expires"`
+}
+
+// CacheStats counts Cache activity. A hit is any lookup which found a usable
+// entry, including expired entries which are then revalidated.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// CachePolicy sets how long responses are fresh for, during which they are
+// served from the cache without contacting the API.
+type CachePolicy struct {
+	// TTL is used for any endpoint not in EndpointTTLs. With a TTL of zero
+	// only responses with an ETag or Last-Modified are cached, to be
+	// revalidated every time.
+	TTL time.Duration
+
+	// EndpointTTLs overrides TTL by the first segment of the request path,
+	// for example "Ratings" or "Establishments". A negative TTL stops that
+	// endpoint from being cached at all.
+	EndpointTTLs map[string]time.Duration
+}
+
+// WithCache caches responses in cache according to policy. Every service is
+// cached, as the cache sits in front of all requests.
+func WithCache(cache Cache, policy CachePolicy) Option {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("Cache must not be nil")
+		}
+
+		c.cache = cache
+		c.cachePolicy = policy
+		return nil
+	}
+}
+
+// usable reports whether the entry can be used at now, because it is fresh or
+// can be revalidated.
+func (e *CacheEntry) usable(now time.Time) bool {
+	return now.Before(e.Expires) || e.ETag != "" || e.LastModified != ""
+}
+
+// ttl returns how long responses from the endpoint for path are fresh for, and
+// false if they should not be cached.
+func (p CachePolicy) ttl(path string) (time.Duration, bool) {
+	endpoint := path
+	if i := strings.IndexAny(endpoint, "/?"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+
+	ttl, ok := p.EndpointTTLs[endpoint]
+	if !ok {
+		ttl = p.TTL
+	}
+
+	return ttl, ttl >= 0
+}
+
+// cacheKey identifies a request. The language and version are included as
+// they change the response.
+func (c *Client) cacheKey(u *url.URL) string {
+	return c.language.String() + " v" + strconv.Itoa(c.version) + " " + u.String()
+}
+
+// cached returns the cache entry for key, or nil.
+func (c *Client) cached(key string) *CacheEntry {
+	if c.cache == nil {
+		return nil
+	}
+
+	entry, ok := c.cache.Get(key)
+	if !ok {
+		return nil
+	}
+
+	// In case the Cache doesn't drop unusable entries itself.
+	if !entry.usable(time.Now()) {
+		c.cache.Delete(key)
+		return nil
+	}
+
+	return entry
+}
+
+// store caches a response body for path. previous is the entry being
+// revalidated, if any, whose validators are kept if the API didn't resend them.
+func (c *Client) store(key, path string, header http.Header, body []byte, previous *CacheEntry) {
+	if c.cache == nil {
+		return
+	}
+
+	ttl, ok := c.cachePolicy.ttl(path)
+	if !ok {
+		return
+	}
+
+	entry := &CacheEntry{
+		Body:         body,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Expires:      time.Now().Add(ttl),
+	}
+
+	if previous != nil {
+		if entry.ETag == "" {
+			entry.ETag = previous.ETag
+		}
+
+		if entry.LastModified == "" {
+			entry.LastModified = previous.LastModified
+		}
+	}
+
+	// With no TTL and nothing to revalidate with, the entry is no use.
+	if !entry.usable(time.Now()) {
+		return
+	}
+
+	c.cache.Set(key, entry)
+}
+
+// MemoryCache is an in-memory Cache which evicts the least recently used entry
+// once it is full.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // Most recently used at the front.
+	stats      CacheStats
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding up to maxEntries responses. If
+// maxEntries is zero there is no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// Get returns the entry for key, if there is one.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		m.stats.Misses++
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryCacheItem).entry
+	if !entry.usable(time.Now()) {
+		m.order.Remove(el)
+		delete(m.entries, key)
+		m.stats.Misses++
+		return nil, false
+	}
+
+	m.stats.Hits++
+	m.order.MoveToFront(el)
+	return entry, true
+}
+
+// Set stores the entry for key, evicting the least recently used entry if the
+// cache is full.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(el)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+		m.stats.Evictions++
+	}
+}
+
+// Delete removes the entry for key.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.order.Remove(el)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// Stats returns the number of hits, misses and evictions so far.
+func (m *MemoryCache) Stats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stats
+}
@@ -0,0 +1,289 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCache_Fresh(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	if err := WithCache(cache, CachePolicy{TTL: time.Hour})(client); err != nil {
+		t.Error(err)
+	}
+
+	calls := 0
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [ { "ratingId": 12 } ] }`)
+	})
+
+	for i := 0; i < 3; i++ {
+		ratings, err := client.Ratings.Get()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if len(ratings.Ratings) != 1 || ratings.Ratings[0].RatingID != 12 {
+			t.Errorf("Expected the cached rating but got %+v", ratings)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call but got %d", calls)
+	}
+
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss but got %+v", stats)
+	}
+
+	// A different language is a different response.
+	if err := client.SetLanguage(LanguageCymraeg); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := client.Ratings.Get(); err != nil {
+		t.Error(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected a new call for a different language but got %d calls", calls)
+	}
+}
+
+func TestCache_Revalidate(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	if err := WithCache(NewMemoryCache(10), CachePolicy{})(client); err != nil {
+		t.Error(err)
+	}
+
+	etag := `"v1"`
+	lastModified := "Mon, 03 Feb 2020 12:00:00 GMT"
+	calls := 0
+	notModified := 0
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls++
+		if calls > 1 {
+			if inm := r.Header.Get("If-None-Match"); inm != etag {
+				t.Errorf("Expected If-None-Match to be %s but got %s", etag, inm)
+			}
+
+			if ims := r.Header.Get("If-Modified-Since"); ims != lastModified {
+				t.Errorf("Expected If-Modified-Since to be %s but got %s", lastModified, ims)
+			}
+
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "FHRSID": 82940, "BusinessName": "Ali's" }`)
+	})
+
+	for i := 0; i < 3; i++ {
+		est, err := client.Establishments.GetByID("82940")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if est.BusinessName != "Ali's" {
+			t.Errorf("Expected the cached establishment but got %+v", est)
+		}
+	}
+
+	if notModified != 2 {
+		t.Errorf("Expected 2 revalidations but got %d", notModified)
+	}
+}
+
+func TestCache_EndpointTTLs(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	policy := CachePolicy{
+		TTL:          time.Hour,
+		EndpointTTLs: map[string]time.Duration{"Establishments": -1},
+	}
+
+	if err := WithCache(NewMemoryCache(10), policy)(client); err != nil {
+		t.Error(err)
+	}
+
+	calls := map[string]int{}
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls["Establishments"]++
+		io.WriteString(w, `{ "establishments": [] }`)
+	})
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls["Ratings"]++
+		io.WriteString(w, `{ "ratings": [] }`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Establishments.Search(&SearchParams{Name: "pizza"}); err != nil {
+			t.Error(err)
+		}
+
+		if _, err := client.Ratings.Get(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if calls["Establishments"] != 2 {
+		t.Errorf("Expected Establishments not to be cached but got %d calls", calls["Establishments"])
+	}
+
+	if calls["Ratings"] != 1 {
+		t.Errorf("Expected Ratings to be cached but got %d calls", calls["Ratings"])
+	}
+}
+
+func TestCache_Errors(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	if err := WithCache(cache, CachePolicy{TTL: time.Hour})(client); err != nil {
+		t.Error(err)
+	}
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.Ratings.Get(); err == nil {
+		t.Error("Expected an error to be returned but got nil")
+	}
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected errors not to be cached but have %d entries", cache.Len())
+	}
+}
+
+func TestCache_NoTTL(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	if err := WithCache(cache, CachePolicy{})(client); err != nil {
+		t.Error(err)
+	}
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [] }`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Ratings.Get(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Without a TTL or validators a response can't be reused.
+	if cache.Len() != 0 {
+		t.Errorf("Expected nothing to be cached but have %d entries", cache.Len())
+	}
+
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Misses != 2 {
+		t.Errorf("Expected 0 hits and 2 misses but got %+v", stats)
+	}
+}
+
+func TestMemoryCache_Expired(t *testing.T) {
+	cache := NewMemoryCache(10)
+	expired := time.Now().Add(-time.Hour)
+
+	cache.Set("a", &CacheEntry{Body: []byte("a"), Expires: expired})
+	cache.Set("b", &CacheEntry{Body: []byte("b"), Expires: expired, ETag: `"v1"`})
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected an expired entry without validators to be a miss")
+	}
+
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("Expected an expired entry with an ETag to be kept for revalidation")
+	}
+
+	expected := CacheStats{Hits: 1, Misses: 1}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Expected %+v but got %+v", expected, stats)
+	}
+
+	if cache.Len() != 1 {
+		t.Errorf("Expected the unusable entry to be dropped but have %d entries", cache.Len())
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+	expires := time.Now().Add(time.Hour)
+
+	cache.Set("a", &CacheEntry{Body: []byte("a"), Expires: expires})
+	cache.Set("b", &CacheEntry{Body: []byte("b"), Expires: expires})
+
+	// Using a makes b the least recently used.
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected a to be cached")
+	}
+
+	cache.Set("c", &CacheEntry{Body: []byte("c"), Expires: expires})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if entry, ok := cache.Get(key); !ok || string(entry.Body) != key {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to be deleted")
+	}
+
+	expected := CacheStats{Hits: 3, Misses: 2, Evictions: 1}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Expected %+v but got %+v", expected, stats)
+	}
+}
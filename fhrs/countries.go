@@ -0,0 +1,115 @@
+package fhrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// CountriesService encapsulates the Countries methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#Countries
+type CountriesService service
+
+// Countries is a list of countries.
+type Countries struct {
+	Countries []Country `json:"countries"`
+	Meta      Meta      `json:"meta"`
+	Links     []Link    `json:"links"`
+}
+
+// Country is a country covered by the API.
+//
+// ID is the value expected by SearchParams.CountryID. The basic endpoints
+// return the same fields without Links.
+type Country struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	NameKey string `json:"nameKey"`
+	Code    string `json:"code"`
+	Links   []Link `json:"links"`
+}
+
+// Get returns the details of all countries.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Countries
+func (s *CountriesService) Get() (*Countries, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *CountriesService) GetContext(ctx context.Context) (*Countries, error) {
+	var countries *Countries
+	if err := s.client.get(ctx, "Countries", &countries); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// GetPage returns a single page of countries.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Countries-pageNumber-pageSize
+func (s *CountriesService) GetPage(pageNumber, pageSize int) (*Countries, error) {
+	return s.GetPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// GetPageContext is like GetPage but uses ctx for the request.
+func (s *CountriesService) GetPageContext(ctx context.Context, pageNumber, pageSize int) (*Countries, error) {
+	var countries *Countries
+	if err := s.client.get(ctx, fmt.Sprintf("Countries/%d/%d", pageNumber, pageSize), &countries); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// GetByID returns the country with the given ID.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Countries-id
+func (s *CountriesService) GetByID(id string) (*Country, error) {
+	return s.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but uses ctx for the request.
+func (s *CountriesService) GetByIDContext(ctx context.Context, id string) (*Country, error) {
+	var country *Country
+	if err := s.client.get(ctx, fmt.Sprintf("Countries/%s", id), &country); err != nil {
+		return nil, err
+	}
+
+	return country, nil
+}
+
+// Basic returns all countries in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Countries-basic
+func (s *CountriesService) Basic() (*Countries, error) {
+	return s.BasicContext(context.Background())
+}
+
+// BasicContext is like Basic but uses ctx for the request.
+func (s *CountriesService) BasicContext(ctx context.Context) (*Countries, error) {
+	var countries *Countries
+	if err := s.client.get(ctx, "Countries/basic", &countries); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// BasicPage returns a single page of countries in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Countries-basic-pageNumber-pageSize
+func (s *CountriesService) BasicPage(pageNumber, pageSize int) (*Countries, error) {
+	return s.BasicPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// BasicPageContext is like BasicPage but uses ctx for the request.
+func (s *CountriesService) BasicPageContext(ctx context.Context, pageNumber, pageSize int) (*Countries, error) {
+	var countries *Countries
+	if err := s.client.get(ctx, fmt.Sprintf("Countries/basic/%d/%d", pageNumber, pageSize), &countries); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
@@ -0,0 +1,221 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCountriesGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "countries": [
+		{
+		  "id": 1,
+		  "name": "England",
+		  "nameKey": "England",
+		  "code": "ENG",
+		  "links": [
+			{
+			  "rel": "self",
+			  "href": "http://api.ratings.food.gov.uk/countries/1"
+			}
+		  ]
+		}
+	  ],
+	  "meta": {
+		"dataSource": "API",
+		"extractDate": "0001-01-01T00:00:00",
+		"itemCount": 1,
+		"returncode": "OK",
+		"totalCount": 1,
+		"totalPages": 1,
+		"pageSize": 1,
+		"pageNumber": 1
+	  },
+	  "links": [
+		{
+		  "rel": "self",
+		  "href": "http://api.ratings.food.gov.uk/countries"
+		}
+	  ]
+	}`
+
+	expected := &Countries{
+		Countries: []Country{
+			{
+				ID:      1,
+				Name:    "England",
+				NameKey: "England",
+				Code:    "ENG",
+				Links: []Link{
+					{
+						Rel:  "self",
+						Href: "http://api.ratings.food.gov.uk/countries/1",
+					},
+				},
+			},
+		},
+		Meta: Meta{
+			DataSource: "API",
+			ItemCount:  1,
+			Returncode: "OK",
+			TotalCount: 1,
+			TotalPages: 1,
+			PageSize:   1,
+			PageNumber: 1,
+		},
+		Links: []Link{
+			{
+				Rel:  "self",
+				Href: "http://api.ratings.food.gov.uk/countries",
+			},
+		},
+	}
+
+	router.GET("/Countries", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/Countries/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "1" {
+			t.Errorf("Expected pageNumber to be 1 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "1" {
+			t.Errorf("Expected pageSize to be 1 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.Countries.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.Countries.GetPage(1, 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestCountriesGetByID(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	idQuery := "4"
+	body := `{ "id": 4, "name": "Wales", "nameKey": "Wales", "code": "WLS", "links": [] }`
+
+	router.GET("/Countries/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if q := p.ByName("id"); q != idQuery {
+			t.Errorf("Expected ID to be %s but got %s", idQuery, q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	expected := &Country{
+		ID:      4,
+		Name:    "Wales",
+		NameKey: "Wales",
+		Code:    "WLS",
+		Links:   []Link{},
+	}
+
+	actual, err := client.Countries.GetByID(idQuery)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestCountriesBasic(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "countries": [
+		{ "id": 1, "name": "England", "nameKey": "England", "code": "ENG" }
+	  ],
+	  "meta": { "itemCount": 1 },
+	  "links": []
+	}`
+
+	expected := &Countries{
+		Countries: []Country{
+			{ID: 1, Name: "England", NameKey: "England", Code: "ENG"},
+		},
+		Meta:  Meta{ItemCount: 1},
+		Links: []Link{},
+	}
+
+	router.GET("/Countries/basic", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/Countries/basic/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "2" {
+			t.Errorf("Expected pageNumber to be 2 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "5" {
+			t.Errorf("Expected pageSize to be 5 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.Countries.Basic()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.Countries.BasicPage(2, 5)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
@@ -0,0 +1,210 @@
+package fhrs
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskCacheExt is the extension of DiskCache entry files.
+const diskCacheExt = ".json"
+
+// DiskCache is a Cache which keeps each response in a file, so entries survive
+// restarts. Once full it evicts the least recently used entry.
+//
+// Failures to read or write the directory are treated as misses, so a broken
+// cache slows the Client down rather than breaking it. The order of use is
+// kept in memory, and on disk as each file's modification time so that it
+// carries over to the next DiskCache on the same directory.
+type DiskCache struct {
+	mu         sync.Mutex
+	dir        string
+	maxEntries int
+	files      map[string]*list.Element
+	order      *list.List // File names, most recently used at the front.
+	stats      CacheStats
+}
+
+// NewDiskCache returns a DiskCache which stores up to maxEntries responses in
+// dir, creating it if needed. If maxEntries is zero there is no limit.
+func NewDiskCache(dir string, maxEntries int) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []os.FileInfo
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), diskCacheExt) {
+			entries = append(entries, info)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	d := &DiskCache{
+		dir:        dir,
+		maxEntries: maxEntries,
+		files:      map[string]*list.Element{},
+		order:      list.New(),
+	}
+
+	for _, info := range entries {
+		d.files[info.Name()] = d.order.PushFront(info.Name())
+	}
+
+	return d, nil
+}
+
+// Get returns the entry for key, if there is one.
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	name := d.name(key)
+	path := filepath.Join(d.dir, name)
+
+	// Files are read and written without holding the lock, so that requests
+	// for different keys don't wait on each other's disk access.
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		d.miss(name)
+		return nil, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || !entry.usable(time.Now()) {
+		os.Remove(path)
+		d.miss(name)
+		return nil, false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Hits++
+	if el, ok := d.files[name]; ok {
+		d.order.MoveToFront(el)
+	}
+
+	return &entry, true
+}
+
+// miss counts a miss for the file name, which is missing or unreadable.
+func (d *DiskCache) miss(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Misses++
+	d.forget(name)
+}
+
+// Set stores the entry for key, evicting the least recently used entry if the
+// cache is full.
+func (d *DiskCache) Set(key string, entry *CacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so readers never see half an entry.
+	f, err := ioutil.TempFile(d.dir, "tmp-")
+	if err != nil {
+		return
+	}
+
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+
+	name := d.name(key)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.Rename(f.Name(), filepath.Join(d.dir, name)); err != nil {
+		os.Remove(f.Name())
+		return
+	}
+
+	if el, ok := d.files[name]; ok {
+		d.order.MoveToFront(el)
+	} else {
+		d.files[name] = d.order.PushFront(name)
+	}
+
+	for d.maxEntries > 0 && d.order.Len() > d.maxEntries {
+		oldest := d.order.Back().Value.(string)
+		if err := os.Remove(filepath.Join(d.dir, oldest)); err == nil || os.IsNotExist(err) {
+			d.stats.Evictions++
+		}
+
+		d.forget(oldest)
+	}
+}
+
+// Delete removes the entry for key.
+func (d *DiskCache) Delete(key string) {
+	name := d.name(key)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	os.Remove(filepath.Join(d.dir, name))
+	d.forget(name)
+}
+
+// Len returns the number of entries in the cache.
+func (d *DiskCache) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.order.Len()
+}
+
+// Stats returns the number of hits, misses and evictions so far.
+func (d *DiskCache) Stats() CacheStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.stats
+}
+
+// name returns the file name for key. Keys are hashed as they are URLs.
+func (d *DiskCache) name(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + diskCacheExt
+}
+
+// path returns the file for key.
+func (d *DiskCache) path(key string) string {
+	return filepath.Join(d.dir, d.name(key))
+}
+
+// forget removes the file name from the index. d.mu must be held.
+func (d *DiskCache) forget(name string) {
+	if el, ok := d.files[name]; ok {
+		d.order.Remove(el)
+		delete(d.files, name)
+	}
+}
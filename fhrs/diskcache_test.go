@@ -0,0 +1,173 @@
+package fhrs
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fhrs-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Date(2020, 2, 3, 12, 0, 0, 0, time.UTC)
+	entry := &CacheEntry{
+		Body:         []byte(`{ "ratings": [] }`),
+		ETag:         `"v1"`,
+		LastModified: "Mon, 03 Feb 2020 12:00:00 GMT",
+		Expires:      expires,
+	}
+
+	cache.Set("a", entry)
+
+	// A new DiskCache on the same directory sees the same entries.
+	reopened, err := NewDiskCache(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, ok := reopened.Get("a")
+	if !ok {
+		t.Fatal("Expected a to be cached")
+	}
+
+	if !reflect.DeepEqual(entry, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", entry, actual)
+	}
+
+	// Make a the oldest entry so that it is evicted first.
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(cache.path("a"), old, old)
+
+	cache.Set("b", entry)
+	cache.Set("c", entry)
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to be evicted")
+	}
+
+	cache.Delete("b")
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be deleted")
+	}
+
+	expected := CacheStats{Misses: 2, Evictions: 1}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Expected %+v but got %+v", expected, stats)
+	}
+}
+
+func TestDiskCache_Reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fhrs-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &CacheEntry{Body: []byte(`{ "ratings": [] }`), Expires: time.Now().Add(time.Hour)}
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, entry)
+	}
+
+	// The order of use on disk carries over, so b is the oldest.
+	for i, key := range []string{"b", "a", "c"} {
+		used := time.Now().Add(time.Duration(i-3) * time.Hour)
+		os.Chtimes(cache.path(key), used, used)
+	}
+
+	reopened, err := NewDiskCache(dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := reopened.Len(); n != 3 {
+		t.Fatalf("Expected 3 entries but got %d", n)
+	}
+
+	reopened.Set("d", entry)
+
+	if _, err := os.Stat(cache.path("b")); !os.IsNotExist(err) {
+		t.Error("Expected b to be evicted")
+	}
+
+	for _, key := range []string{"a", "c", "d"} {
+		if _, ok := reopened.Get(key); !ok {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+
+	if n := reopened.Len(); n != 3 {
+		t.Errorf("Expected 3 entries but got %d", n)
+	}
+}
+
+func TestDiskCache_Corrupt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fhrs-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(cache.path("a"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a corrupt entry to be a miss")
+	}
+
+	if _, err := os.Stat(cache.path("a")); !os.IsNotExist(err) {
+		t.Error("Expected a corrupt entry to be removed")
+	}
+}
+
+func TestDiskCache_Expired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fhrs-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Set("a", &CacheEntry{Body: []byte("{}"), Expires: time.Now().Add(-time.Hour)})
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected an expired entry without validators to be a miss")
+	}
+
+	if _, err := os.Stat(cache.path("a")); !os.IsNotExist(err) {
+		t.Error("Expected the unusable entry to be removed")
+	}
+
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Misses != 1 {
+		t.Errorf("Expected 0 hits and 1 miss but got %+v", stats)
+	}
+}
@@ -0,0 +1,144 @@
+package fhrs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize is the most of an error response body that will be read.
+// Error pages can be large and are only kept for diagnostics.
+const maxErrorBodySize = 64 << 10
+
+// ErrNotFound is returned when the requested resource does not exist. The
+// returned error is an APIError, so use errors.Is to check for it:
+//
+//	if errors.Is(err, fhrs.ErrNotFound) {
+//		// Handle the missing establishment.
+//	}
+var ErrNotFound = errors.New("Not found")
+
+// ErrEmptyResponse is returned when the API responds successfully but with an
+// empty body, so there is nothing to return.
+var ErrEmptyResponse = errors.New("Empty response")
+
+// ErrorResponse is the body returned when an error occurs.
+type ErrorResponse struct {
+	Message string `json:"Message"`
+}
+
+// problemDetails is an RFC 7807 error body, as returned by some proxies in
+// front of the API.
+type problemDetails struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// APIError encapsulated a general error coming from an API request. This is for
+// the cases which do not have specific errors.
+//
+// Body holds the start of the raw response body, up to 64KiB, and Header the
+// response headers, for diagnostics.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Message    string
+	Body       []byte
+	Header     http.Header
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf(
+		"API Error: %s %s returned status %d. %s",
+		e.Method, e.URL, e.StatusCode, e.Message,
+	)
+}
+
+// Is reports whether the error matches target. A 404 response matches
+// ErrNotFound.
+func (e APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsClientError reports whether the API rejected the request (4xx).
+func (e APIError) IsClientError() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+// IsServerError reports whether the API failed to handle the request (5xx).
+func (e APIError) IsServerError() bool {
+	return e.StatusCode >= 500 && e.StatusCode < 600
+}
+
+// IsRateLimited reports whether the request was throttled by the API.
+func (e APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsRetryable reports whether the same request may succeed if made again.
+// This covers throttling, timeouts and temporary unavailability, but not a
+// plain 500 which is usually caused by the request itself.
+func (e APIError) IsRetryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// newAPIError builds an APIError from an unsuccessful response.
+func newAPIError(req *http.Request, res *http.Response) error {
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	if err != nil {
+		return err
+	}
+
+	return APIError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: res.StatusCode,
+		Message:    errorMessage(res.Header.Get("Content-Type"), body),
+		Body:       body,
+		Header:     res.Header,
+	}
+}
+
+// errorMessage extracts a message from an error body based on its content type.
+// Bodies which are not JSON, or which fail to decode, are used as-is.
+func errorMessage(contentType string, body []byte) string {
+	// A missing or malformed Content-Type leaves mediaType empty, in which
+	// case we try JSON as that's what the API normally returns.
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == ContentTypeProblemJSON:
+		var problem problemDetails
+		if err := json.Unmarshal(body, &problem); err == nil {
+			if problem.Detail != "" {
+				return problem.Detail
+			}
+
+			return problem.Title
+		}
+	case mediaType == ContentTypeJSON, strings.HasSuffix(mediaType, "+json"), mediaType == "":
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(body, &errorResponse); err == nil {
+			return errorResponse.Message
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,221 @@
+package fhrs
+
+import (
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	notFound := APIError{StatusCode: http.StatusNotFound}
+	if !errors.Is(notFound, ErrNotFound) {
+		t.Error("Expected a 404 APIError to match ErrNotFound")
+	}
+
+	wrapped := fmt.Errorf("fetching establishment: %w", notFound)
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Error("Expected a wrapped 404 APIError to match ErrNotFound")
+	}
+
+	var apiErr APIError
+	if !errors.As(wrapped, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Error("Expected a wrapped 404 APIError to be extracted with errors.As")
+	}
+
+	if errors.Is(APIError{StatusCode: http.StatusBadRequest}, ErrNotFound) {
+		t.Error("Expected a 400 APIError not to match ErrNotFound")
+	}
+}
+
+func TestAPIErrorClassification(t *testing.T) {
+	cases := []struct {
+		status      int
+		client      bool
+		server      bool
+		rateLimited bool
+		retryable   bool
+	}{
+		{status: http.StatusBadRequest, client: true},
+		{status: http.StatusNotFound, client: true},
+		{status: http.StatusRequestTimeout, client: true, retryable: true},
+		{status: http.StatusTooManyRequests, client: true, rateLimited: true, retryable: true},
+		{status: http.StatusInternalServerError, server: true},
+		{status: http.StatusBadGateway, server: true, retryable: true},
+		{status: http.StatusServiceUnavailable, server: true, retryable: true},
+		{status: http.StatusGatewayTimeout, server: true, retryable: true},
+	}
+
+	for _, c := range cases {
+		e := APIError{StatusCode: c.status}
+
+		if e.IsClientError() != c.client {
+			t.Errorf("Expected IsClientError for %d to be %t", c.status, c.client)
+		}
+
+		if e.IsServerError() != c.server {
+			t.Errorf("Expected IsServerError for %d to be %t", c.status, c.server)
+		}
+
+		if e.IsRateLimited() != c.rateLimited {
+			t.Errorf("Expected IsRateLimited for %d to be %t", c.status, c.rateLimited)
+		}
+
+		if e.IsRetryable() != c.retryable {
+			t.Errorf("Expected IsRetryable for %d to be %t", c.status, c.retryable)
+		}
+	}
+}
+
+func TestAPIError_ContentTypes(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	cases := []struct {
+		id          string
+		contentType string
+		body        string
+		message     string
+	}{
+		{
+			id:          "charset",
+			contentType: "application/json; charset=utf-8",
+			body:        `{ "Message": "The request is invalid" }`,
+			message:     "The request is invalid",
+		},
+		{
+			id:          "problem",
+			contentType: "application/problem+json",
+			body:        `{ "title": "Bad Request", "status": 400, "detail": "Invalid id" }`,
+			message:     "Invalid id",
+		},
+		{
+			id:          "text",
+			contentType: "text/plain; charset=utf-8",
+			body:        "Bad Request\n",
+			message:     "Bad Request",
+		},
+		{
+			id:          "html",
+			contentType: "text/html",
+			body:        "<h1>Bad Request</h1>",
+			message:     "<h1>Bad Request</h1>",
+		},
+		{
+			id:      "missing-json",
+			body:    `{ "Message": "The request is invalid" }`,
+			message: "The request is invalid",
+		},
+		{
+			id:      "missing-text",
+			body:    "Bad Request",
+			message: "Bad Request",
+		},
+		{
+			id:          "truncated",
+			contentType: "application/json",
+			body:        `{ "Message": "The requ`,
+			message:     `{ "Message": "The requ`,
+		},
+	}
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		for _, c := range cases {
+			if c.id == p.ByName("id") {
+				// Stop the server sniffing a Content-Type for us.
+				w.Header()["Content-Type"] = nil
+				if c.contentType != "" {
+					w.Header().Set("Content-Type", c.contentType)
+				}
+
+				w.Header().Set("X-Request-Id", c.id)
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, c.body)
+			}
+		}
+	})
+
+	for _, c := range cases {
+		_, err := client.Establishments.GetByID(c.id)
+
+		var apiErr APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("%s: expected err to be APIError but type is %T", c.id, err)
+			continue
+		}
+
+		if apiErr.Message != c.message {
+			t.Errorf("%s: expected message to be %q but got %q", c.id, c.message, apiErr.Message)
+		}
+
+		if string(apiErr.Body) != c.body {
+			t.Errorf("%s: expected body to be %q but got %q", c.id, c.body, apiErr.Body)
+		}
+
+		if h := apiErr.Header.Get("X-Request-Id"); h != c.id {
+			t.Errorf("%s: expected X-Request-Id header to be %s but got %s", c.id, c.id, h)
+		}
+	}
+}
+
+func TestAPIError_LargeBody(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, strings.Repeat("x", maxErrorBodySize*2))
+	})
+
+	_, err = client.Ratings.Get()
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected err to be APIError but type is %T", err)
+	}
+
+	if len(apiErr.Body) != maxErrorBodySize {
+		t.Errorf("Expected body to be capped at %d bytes but got %d", maxErrorBodySize, len(apiErr.Body))
+	}
+}
+
+func TestEmptyResponse(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	bodies := map[string]string{"1": "", "2": "  \n", "3": "null"}
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, bodies[p.ByName("id")])
+	})
+
+	for id := range bodies {
+		establishment, err := client.Establishments.GetByID(id)
+		if !errors.Is(err, ErrEmptyResponse) {
+			t.Errorf("Expected ErrEmptyResponse for body %q but got %v", bodies[id], err)
+		}
+
+		if establishment != nil {
+			t.Errorf("Expected no establishment for body %q", bodies[id])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package fhrs
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -57,6 +58,25 @@ type Establishment struct {
 	Links                      []Link    `json:"links"`
 }
 
+// BasicEstablishments is a list of establishments in the basic format.
+type BasicEstablishments struct {
+	Establishments []BasicEstablishment `json:"establishments"`
+	Meta           Meta                 `json:"meta"`
+	Links          []Link               `json:"links"`
+}
+
+// BasicEstablishment is the reduced set of establishment details returned by
+// the basic endpoints.
+type BasicEstablishment struct {
+	FHRSID                   int       `json:"FHRSID"`
+	LocalAuthorityBusinessID string    `json:"LocalAuthorityBusinessID"`
+	BusinessName             string    `json:"BusinessName"`
+	RatingValue              string    `json:"RatingValue"`
+	RatingKey                string    `json:"RatingKey"`
+	RatingDate               Timestamp `json:"RatingDate"`
+	Links                    []Link    `json:"links"`
+}
+
 // SearchParams are the parameters available for searching for establishments.
 type SearchParams struct {
 	Name              string
@@ -65,12 +85,12 @@ type SearchParams struct {
 	Latitude          *float64
 	MaxDistanceLimit  *int
 	BusinessTypeID    string
-	SchemeTypeKey     string
+	SchemeTypeKey     SchemeType
 	RatingKey         string
-	RatingOperatorKey string
+	RatingOperatorKey RatingOperator
 	LocalAuthorityID  string
 	CountryID         string
-	SortOptionKey     string
+	SortOptionKey     SortOption
 	PageNumber        *int
 	PageSize          *int
 }
@@ -79,8 +99,13 @@ type SearchParams struct {
 //
 // https://api.ratings.food.gov.uk/Help/Api/GET-Establishments-id
 func (s *EstablishmentsService) GetByID(id string) (*Establishment, error) {
+	return s.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but uses ctx for the request.
+func (s *EstablishmentsService) GetByIDContext(ctx context.Context, id string) (*Establishment, error) {
 	var establishment *Establishment
-	if err := s.client.get(fmt.Sprintf("Establishments/%s", id), &establishment); err != nil {
+	if err := s.client.get(ctx, fmt.Sprintf("Establishments/%s", id), &establishment); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +117,11 @@ func (s *EstablishmentsService) GetByID(id string) (*Establishment, error) {
 // https://api.ratings.food.gov.uk/Help/Api/GET-Establishments_name_address_longitude_latitude_maxDistanceLimit
 // _businessTypeId_schemeTypeKey_ratingKey_ratingOperatorKey_localAuthorityId_countryId_sortOptionKey_pageNumber_pageSize
 func (s *EstablishmentsService) Search(params *SearchParams) (*Establishments, error) {
+	return s.SearchContext(context.Background(), params)
+}
+
+// SearchContext is like Search but uses ctx for the request.
+func (s *EstablishmentsService) SearchContext(ctx context.Context, params *SearchParams) (*Establishments, error) {
 	var establishments *Establishments
 	u := url.URL{Path: "Establishments"}
 	q := u.Query()
@@ -118,13 +148,13 @@ func (s *EstablishmentsService) Search(params *SearchParams) (*Establishments, e
 			q.Set("businessTypeId", params.BusinessTypeID)
 		}
 		if params.SchemeTypeKey != "" {
-			q.Set("schemeTypeKey", params.SchemeTypeKey)
+			q.Set("schemeTypeKey", string(params.SchemeTypeKey))
 		}
 		if params.RatingKey != "" {
 			q.Set("ratingKey", params.RatingKey)
 		}
 		if params.RatingOperatorKey != "" {
-			q.Set("ratingOperatorKey", params.RatingOperatorKey)
+			q.Set("ratingOperatorKey", string(params.RatingOperatorKey))
 		}
 		if params.LocalAuthorityID != "" {
 			q.Set("localAuthorityId", params.LocalAuthorityID)
@@ -133,7 +163,7 @@ func (s *EstablishmentsService) Search(params *SearchParams) (*Establishments, e
 			q.Set("countryId", params.CountryID)
 		}
 		if params.SortOptionKey != "" {
-			q.Set("sortOptionKey", params.SortOptionKey)
+			q.Set("sortOptionKey", string(params.SortOptionKey))
 		}
 		if params.PageNumber != nil {
 			q.Set("pageNumber", strconv.Itoa(*params.PageNumber))
@@ -144,7 +174,33 @@ func (s *EstablishmentsService) Search(params *SearchParams) (*Establishments, e
 	}
 
 	u.RawQuery = q.Encode()
-	if err := s.client.get(u.String(), &establishments); err != nil {
+	if err := s.client.get(ctx, u.String(), &establishments); err != nil {
+		return nil, err
+	}
+
+	return establishments, nil
+}
+
+// Basic returns establishments in the basic format.
+//
+// If pageNumber and pageSize are both zero, the unpaged endpoint is used and
+// the page is chosen by the API.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Establishments-basic
+// https://api.ratings.food.gov.uk/Help/Api/GET-Establishments-basic-pageNumber-pageSize
+func (s *EstablishmentsService) Basic(pageNumber, pageSize int) (*BasicEstablishments, error) {
+	return s.BasicContext(context.Background(), pageNumber, pageSize)
+}
+
+// BasicContext is like Basic but uses ctx for the request.
+func (s *EstablishmentsService) BasicContext(ctx context.Context, pageNumber, pageSize int) (*BasicEstablishments, error) {
+	var establishments *BasicEstablishments
+	path := "Establishments/basic"
+	if pageNumber != 0 || pageSize != 0 {
+		path = fmt.Sprintf("Establishments/basic/%d/%d", pageNumber, pageSize)
+	}
+
+	if err := s.client.get(ctx, path, &establishments); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package fhrs
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/julienschmidt/httprouter"
@@ -70,7 +71,7 @@ func TestGetByID(t *testing.T) {
 	  ]
 	}`
 
-	rd, _ := time.Parse("2006-01-02T15:04:05", "2019-08-06T00:00:00")
+	rd, _ := time.ParseInLocation("2006-01-02T15:04:05", "2019-08-06T00:00:00", ukLocation)
 	ed, _ := time.Parse("2006-01-02T15:04:05", "0001-01-01T00:00:00")
 
 	expected := &Establishment{
@@ -196,8 +197,8 @@ func TestGetByID_NotFound(t *testing.T) {
 	})
 
 	est, err := client.Establishments.GetByID(idQuery)
-	if err != nil {
-		t.Error(err)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected err to be ErrNotFound but got %v", err)
 	}
 
 	if est != nil {
@@ -237,8 +238,8 @@ func TestGetByID_Headers(t *testing.T) {
 	}
 
 	_, err = client.Establishments.GetByID(idQuery)
-	if err != nil {
-		t.Error(err)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected err to be ErrNotFound but got %v", err)
 	}
 }
 
@@ -321,7 +322,7 @@ func TestSearch(t *testing.T) {
 	  ]
 	}`
 
-	rd, _ := time.Parse("2006-01-02T15:04:05", "2019-08-06T00:00:00")
+	rd, _ := time.ParseInLocation("2006-01-02T15:04:05", "2019-08-06T00:00:00", ukLocation)
 	ed, _ := time.Parse("2006-01-02T15:04:05", "0001-01-01T00:00:00")
 
 	expected := &Establishments{
@@ -531,17 +532,17 @@ func TestSearch_Params(t *testing.T) {
 		"latitude":          "-0.8231",
 		"maxDistanceLimit":  "10",
 		"businessTypeId":    params.BusinessTypeID,
-		"schemeTypeKey":     params.SchemeTypeKey,
+		"schemeTypeKey":     string(params.SchemeTypeKey),
 		"ratingKey":         params.RatingKey,
-		"ratingOperatorKey": params.RatingOperatorKey,
+		"ratingOperatorKey": string(params.RatingOperatorKey),
 		"localAuthorityId":  params.LocalAuthorityID,
 		"countryId":         params.CountryID,
-		"sortOptionKey":     params.SortOptionKey,
+		"sortOptionKey":     string(params.SortOptionKey),
 		"pageNumber":        "1",
 		"pageSize":          "20",
 	}
 
-	router.GET("/Establissments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		q := r.URL.Query()
 
 		for p, expected := range testValues {
@@ -550,6 +551,9 @@ func TestSearch_Params(t *testing.T) {
 				t.Errorf(paramError(p, expected, actual))
 			}
 		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "establishments": [] }`)
 	})
 
 	_, err = client.Establishments.Search(params)
@@ -557,3 +561,137 @@ func TestSearch_Params(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBasic(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "establishments": [
+		{
+		  "FHRSID": 82940,
+		  "LocalAuthorityBusinessID": "2019",
+		  "BusinessName": "Ali's",
+		  "RatingValue": "3",
+		  "RatingKey": "fhrs_3_en-gb",
+		  "RatingDate": "2019-08-06T00:00:00",
+		  "links": [
+			{
+			  "rel": "self",
+			  "href": "http://api.ratings.food.gov.uk/establishments/82940"
+			}
+		  ]
+		}
+	  ],
+	  "meta": {
+		"dataSource": "Lucene",
+		"extractDate": "0001-01-01T00:00:00",
+		"itemCount": 1,
+		"returncode": "OK",
+		"totalCount": 1,
+		"totalPages": 1,
+		"pageSize": 1,
+		"pageNumber": 1
+	  },
+	  "links": []
+	}`
+
+	rd, _ := time.ParseInLocation("2006-01-02T15:04:05", "2019-08-06T00:00:00", ukLocation)
+
+	expected := &BasicEstablishments{
+		Establishments: []BasicEstablishment{
+			{
+				FHRSID:                   82940,
+				LocalAuthorityBusinessID: "2019",
+				BusinessName:             "Ali's",
+				RatingValue:              "3",
+				RatingKey:                "fhrs_3_en-gb",
+				RatingDate:               Timestamp(rd),
+				Links: []Link{
+					{
+						Rel:  "self",
+						Href: "http://api.ratings.food.gov.uk/establishments/82940",
+					},
+				},
+			},
+		},
+		Meta: Meta{
+			DataSource: "Lucene",
+			ItemCount:  1,
+			Returncode: "OK",
+			TotalCount: 1,
+			TotalPages: 1,
+			PageSize:   1,
+			PageNumber: 1,
+		},
+		Links: []Link{},
+	}
+
+	router.GET("/Establishments/basic", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	router.GET("/Establishments/basic/:pageNumber/:pageSize", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if pn := p.ByName("pageNumber"); pn != "4" {
+			t.Errorf("Expected pageNumber to be 4 but got %s", pn)
+		}
+
+		if ps := p.ByName("pageSize"); ps != "100" {
+			t.Errorf("Expected pageSize to be 100 but got %s", ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.Establishments.Basic(0, 0)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	actual, err = client.Establishments.Basic(4, 100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
+
+func TestGetByIDContext_Canceled(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		// Hold the request open until the client gives up.
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	est, err := client.Establishments.GetByIDContext(ctx, "82940")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected err to be context.DeadlineExceeded but got %v", err)
+	}
+
+	if est != nil {
+		t.Errorf("Expected response to be nil, but got %v", est)
+	}
+}
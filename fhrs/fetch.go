@@ -0,0 +1,185 @@
+package fhrs
+
+import (
+	"context"
+)
+
+// PageResult is a page of establishments from SearchPages. If Err is set the
+// page could not be fetched and it is the last result sent.
+type PageResult struct {
+	PageNumber     int
+	Establishments []Establishment
+	Meta           Meta
+	Err            error
+}
+
+// SearchPages fetches every page of results for params, using up to workers
+// concurrent requests, and sends them in page order on the returned channel.
+//
+// The first page is fetched alone to find the number of pages, then the rest
+// are shared between the workers. Establishments which have moved onto a page
+// that was already sent are removed, so each is seen once.
+//
+// The channel is closed once every page has been sent, after the first error,
+// or when ctx is done. If ctx is done first the last result has ctx.Err() as
+// its Err, so a walk which was cut short can be told from a complete one.
+// Callers which stop reading early should cancel ctx so the workers stop.
+func (s *EstablishmentsService) SearchPages(ctx context.Context, params *SearchParams, workers int) <-chan PageResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// One slot of buffer means the error for a cancelled walk can always be
+	// sent, even if the caller has stopped reading.
+	out := make(chan PageResult, 1)
+
+	go func() {
+		defer close(out)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var base SearchParams
+		if params != nil {
+			base = *params
+		}
+
+		first := 1
+		if base.PageNumber != nil {
+			first = *base.PageNumber
+		}
+
+		fetch := func(pageNumber int) PageResult {
+			p := base
+			p.PageNumber = &pageNumber
+
+			result := PageResult{PageNumber: pageNumber}
+			res, err := s.SearchContext(ctx, &p)
+			if err != nil {
+				result.Err = err
+			} else {
+				result.Establishments = res.Establishments
+				result.Meta = res.Meta
+			}
+
+			return result
+		}
+
+		// canceled sends ctx's error as the last result, in place of the
+		// page with pageNumber.
+		canceled := func(pageNumber int) {
+			result := PageResult{PageNumber: pageNumber, Err: ctx.Err()}
+
+			select {
+			case out <- result:
+				return
+			default:
+			}
+
+			// An unread page is in the way. The walk has been cut short
+			// anyway, so the error takes its place.
+			select {
+			case unread := <-out:
+				result.PageNumber = unread.PageNumber
+			default:
+			}
+
+			out <- result
+		}
+
+		seen := map[int]bool{}
+		send := func(result PageResult) bool {
+			if result.Err == nil {
+				result.Establishments = dedupe(result.Establishments, seen)
+			}
+
+			select {
+			case out <- result:
+				return result.Err == nil
+			case <-ctx.Done():
+				canceled(result.PageNumber)
+				return false
+			}
+		}
+
+		result := fetch(first)
+		if !send(result) {
+			return
+		}
+
+		last := result.Meta.TotalPages
+		if last <= first {
+			return
+		}
+
+		// Each page gets its own buffered slot so workers never block, and
+		// the window stops them running too far ahead of a slow reader.
+		slots := make([]chan PageResult, last-first)
+		for i := range slots {
+			slots[i] = make(chan PageResult, 1)
+		}
+
+		window := make(chan struct{}, workers*2)
+		jobs := make(chan int)
+
+		go func() {
+			defer close(jobs)
+
+			for i := range slots {
+				select {
+				case window <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		for w := 0; w < workers; w++ {
+			go func() {
+				for i := range jobs {
+					slots[i] <- fetch(first + 1 + i)
+				}
+			}()
+		}
+
+		for i, slot := range slots {
+			var result PageResult
+			select {
+			case result = <-slot:
+			case <-ctx.Done():
+				canceled(first + 1 + i)
+				return
+			}
+
+			if !send(result) {
+				return
+			}
+
+			<-window
+		}
+	}()
+
+	return out
+}
+
+// dedupe removes establishments which have already been seen, and records the
+// rest as seen.
+func dedupe(establishments []Establishment, seen map[int]bool) []Establishment {
+	unique := establishments[:0]
+	for _, e := range establishments {
+		if seen[e.FHRSID] {
+			continue
+		}
+
+		seen[e.FHRSID] = true
+		unique = append(unique, e)
+	}
+
+	return unique
+}
@@ -0,0 +1,201 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchPages(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	var inFlight, maxInFlight int32
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+
+		// Make later pages quicker so they finish out of order.
+		time.Sleep(time.Duration(6-pageNumber) * 5 * time.Millisecond)
+
+		// Establishment 20 moves from page 2 to page 3 between requests.
+		ids := []int{pageNumber * 10, pageNumber*10 + 1}
+		if pageNumber == 3 {
+			ids = append(ids, 20)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, pageBody(pageNumber, 5, ids...))
+	})
+
+	var pages []int
+	var ids []int
+	for result := range client.Establishments.SearchPages(context.Background(), nil, 3) {
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+
+		pages = append(pages, result.PageNumber)
+		for _, e := range result.Establishments {
+			ids = append(ids, e.FHRSID)
+		}
+	}
+
+	if fmt.Sprint(pages) != "[1 2 3 4 5]" {
+		t.Errorf("Expected pages [1 2 3 4 5] in order but got %v", pages)
+	}
+
+	if fmt.Sprint(ids) != "[10 11 20 21 30 31 40 41 50 51]" {
+		t.Errorf("Expected each establishment once but got %v", ids)
+	}
+
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 concurrent requests but saw %d", maxInFlight)
+	}
+}
+
+func TestSearchPages_Error(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if pageNumber == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{ "Message": "The request is invalid" }`)
+			return
+		}
+
+		io.WriteString(w, pageBody(pageNumber, 10, pageNumber))
+	})
+
+	var results []PageResult
+	for result := range client.Establishments.SearchPages(context.Background(), nil, 4) {
+		results = append(results, result)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 2 pages and an error but got %d results", len(results))
+	}
+
+	last := results[2]
+	if last.PageNumber != 3 {
+		t.Errorf("Expected the error to be for page 3 but got page %d", last.PageNumber)
+	}
+
+	if _, ok := last.Err.(APIError); !ok {
+		t.Errorf("Expected err to be APIError but type is %T", last.Err)
+	}
+}
+
+func TestSearchPages_Canceled(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, pageBody(pageNumber, 1000, pageNumber))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	var last PageResult
+	for result := range client.Establishments.SearchPages(ctx, nil, 2) {
+		count++
+		last = result
+		if count == 3 {
+			cancel()
+		}
+	}
+
+	if count >= 1000 {
+		t.Errorf("Expected cancellation to stop the fetch early but got %d pages", count)
+	}
+
+	if !errors.Is(last.Err, context.Canceled) {
+		t.Errorf("Expected the last result to have context.Canceled but got %v", last.Err)
+	}
+}
+
+func TestSearchPages_Deadline(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		if pageNumber > 1 {
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, pageBody(pageNumber, 1000, pageNumber))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var results []PageResult
+	for result := range client.Establishments.SearchPages(ctx, nil, 1) {
+		results = append(results, result)
+	}
+
+	if len(results) < 2 || len(results) >= 1000 {
+		t.Fatalf("Expected the deadline to stop the fetch early but got %d results", len(results))
+	}
+
+	for _, result := range results[:len(results)-1] {
+		if result.Err != nil {
+			t.Errorf("Unexpected error on page %d: %v", result.PageNumber, result.Err)
+		}
+	}
+
+	last := results[len(results)-1]
+	if !errors.Is(last.Err, context.DeadlineExceeded) {
+		t.Errorf("Expected the last result to have context.DeadlineExceeded but got %v", last.Err)
+	}
+
+	if want := results[len(results)-2].PageNumber + 1; last.PageNumber != want {
+		t.Errorf("Expected the error for page %d but got page %d", want, last.PageNumber)
+	}
+}
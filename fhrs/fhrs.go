@@ -1,26 +1,27 @@
 package fhrs
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 	"time"
 )
 
 const (
-	endpoint = "https://api.ratings.food.gov.uk/"
-	version  = 2
+	endpoint       = "https://api.ratings.food.gov.uk/"
+	version        = 2
+	defaultTimeout = 15 * time.Second
 )
 
 const (
-	ContentTypeJSON = "application/json"
-	ContentTypeHTML = "text/html"
+	ContentTypeJSON        = "application/json"
+	ContentTypeProblemJSON = "application/problem+json"
+	ContentTypeHTML        = "text/html"
 )
 
 // APILanguage represents the language API responses will be returned in.
@@ -35,65 +36,6 @@ func (l APILanguage) String() string {
 	return []string{"en-GB", "cy-GB"}[l]
 }
 
-// APIError encapsulated a general error coming from an API request. This is for
-// the cases which do not have specific errors.
-type APIError struct {
-	Method     string
-	URL        string
-	StatusCode int
-	Message    string
-}
-
-func (e APIError) Error() string {
-	return fmt.Sprintf(
-		"API Error: %s %s returned status %d. %s",
-		e.Method, e.URL, e.StatusCode, e.Message,
-	)
-}
-
-// Timestamp is a representation of the date/time format used throughout the API.
-//
-// It is mostly RFC3339 with the timezone omitted, but this is not consistent, so
-// both variations can be parsed as JSON.
-type Timestamp time.Time
-
-func (t *Timestamp) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
-	if s == "null" || s == "" || s == "undefined" {
-		*t = Timestamp(time.Time{})
-		return nil
-	}
-
-	parsed, err := time.Parse("2006-01-02T15:04:05", s)
-	if err == nil {
-		*t = Timestamp(parsed)
-		return nil
-	}
-
-	// If we can't parse as the above, try RFC3339.
-	parsed, err = time.Parse(time.RFC3339, s)
-	if err != nil {
-		return err
-	}
-
-	*t = Timestamp(parsed)
-	return nil
-}
-
-func (t *Timestamp) MarshalJSON() ([]byte, error) {
-	return json.Marshal(t)
-}
-
-func (t Timestamp) String() string {
-	ts := time.Time(t)
-	return ts.String()
-}
-
-// ErrorResponse is the body returned when an error occurs.
-type ErrorResponse struct {
-	Message string `json:"Message"`
-}
-
 // Meta is the metadata returned with most payloads in the API.
 type Meta struct {
 	DataSource  string    `json:"dataSource"`
@@ -114,14 +56,28 @@ type Link struct {
 
 // Client provides the entry point to all of the available services.
 type Client struct {
-	httpClient *http.Client
-	language   APILanguage
-	baseURL    *url.URL
-	version    int
-	common     service // Reuse this for all services.
-
-	Establishments *EstablishmentsService
-	Ratings        *RatingsService
+	httpClient  *http.Client
+	language    APILanguage
+	baseURL     *url.URL
+	version     int
+	userAgent   string
+	timeout     time.Duration
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	cache       Cache
+	cachePolicy CachePolicy
+	common      service // Reuse this for all services.
+
+	Authorities      *AuthoritiesService
+	BusinessTypes    *BusinessTypesService
+	Countries        *CountriesService
+	Establishments   *EstablishmentsService
+	RatingOperators  *RatingOperatorsService
+	Ratings          *RatingsService
+	Regions          *RegionsService
+	SchemeTypes      *SchemeTypesService
+	ScoreDescriptors *ScoreDescriptorsService
+	SortOptions      *SortOptionsService
 }
 
 type service struct {
@@ -129,23 +85,45 @@ type service struct {
 }
 
 // NewClient creates a new FHRS Client.
-func NewClient() (*Client, error) {
-	httpClient := &http.Client{Timeout: 15 * time.Second}
-
+//
+// By default the Client talks to the public API with a 15 second timeout. This
+// can be changed by passing Options.
+func NewClient(opts ...Option) (*Client, error) {
 	baseURL, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Client{
-		httpClient: httpClient,
+		httpClient: &http.Client{Timeout: defaultTimeout},
 		baseURL:    baseURL,
 		version:    version,
 	}
 
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+
+	if client.timeout != 0 {
+		// Copy so we never modify an http.Client we were given.
+		httpClient := *client.httpClient
+		httpClient.Timeout = client.timeout
+		client.httpClient = &httpClient
+	}
+
 	client.common.client = client
+	client.Authorities = (*AuthoritiesService)(&client.common)
+	client.BusinessTypes = (*BusinessTypesService)(&client.common)
+	client.Countries = (*CountriesService)(&client.common)
 	client.Establishments = (*EstablishmentsService)(&client.common)
+	client.RatingOperators = (*RatingOperatorsService)(&client.common)
 	client.Ratings = (*RatingsService)(&client.common)
+	client.Regions = (*RegionsService)(&client.common)
+	client.SchemeTypes = (*SchemeTypesService)(&client.common)
+	client.ScoreDescriptors = (*ScoreDescriptorsService)(&client.common)
+	client.SortOptions = (*SortOptionsService)(&client.common)
 
 	return client, nil
 }
@@ -161,67 +139,137 @@ func (c *Client) SetLanguage(l APILanguage) error {
 	return errors.New("Language not supported")
 }
 
-func (c *Client) get(url string, responseBody interface{}) error {
+func (c *Client) get(ctx context.Context, url string, responseBody interface{}) error {
 	u, err := c.baseURL.Parse(url)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return err
+	key := c.cacheKey(u)
+	entry := c.cached(key)
+	if entry != nil && time.Now().Before(entry.Expires) {
+		return decode(entry.Body, responseBody)
 	}
 
-	req.Header.Set("x-api-version", strconv.Itoa(c.version))
-	req.Header.Set("Accept-Language", c.language.String())
+	header := http.Header{}
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
 
-	res, err := c.httpClient.Do(req)
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	res, body, err := c.send(ctx, u.String(), header)
 	if err != nil {
 		return err
 	}
 
-	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified && entry != nil {
+		body = entry.Body
+	}
 
-	switch {
-	// 404: Simply return nil to denote nothing to return.
-	case res.StatusCode == http.StatusNotFound:
-		return nil
-	// Otherwise parse and return general API error.
-	case res.StatusCode < 200 || res.StatusCode >= 300:
-		var errorResponse ErrorResponse
+	c.store(key, url, res.Header, body, entry)
+
+	return decode(body, responseBody)
+}
 
-		if res.Header["Content-Type"][0] == ContentTypeHTML {
-			body, err := ioutil.ReadAll(res.Body)
-			if err != nil {
-				return err
-			}
+// send makes a GET request, retrying according to the retry policy, and
+// returns the response with its body read and closed. The body is read within
+// each attempt so that a connection lost part way through is retried too.
+func (c *Client) send(ctx context.Context, url string, header http.Header) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		res, body, err := c.read(ctx, url, header)
+		if err == nil {
+			return res, body, nil
+		}
 
-			errorResponse.Message = string(body)
+		delay, ok := c.retryPolicy.delay(http.MethodGet, attempt, err)
+		if !ok {
+			return nil, nil, err
 		}
 
-		if res.Header["Content-Type"][0] == ContentTypeJSON {
-			if err := json.NewDecoder(res.Body).Decode(&errorResponse); err != nil {
-				if err != io.EOF {
-					return err
-				}
-			}
+		if c.retryPolicy.OnRetry != nil {
+			c.retryPolicy.OnRetry(RetryEvent{
+				Method:  http.MethodGet,
+				URL:     url,
+				Attempt: attempt,
+				Err:     err,
+				Delay:   delay,
+			})
 		}
 
-		return APIError{
-			Method:     req.Method,
-			URL:        req.URL.String(),
-			StatusCode: res.StatusCode,
-			Message:    errorResponse.Message,
+		if err := sleep(ctx, delay); err != nil {
+			return nil, nil, err
 		}
 	}
+}
+
+// read makes a single GET request and reads the response body.
+func (c *Client) read(ctx context.Context, url string, header http.Header) (*http.Response, []byte, error) {
+	res, err := c.do(ctx, http.MethodGet, url, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, body, nil
+}
+
+// decode unmarshals a response body. An empty or null body returns
+// ErrEmptyResponse, so callers never get a nil result without an error.
+func decode(body []byte, responseBody interface{}) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return ErrEmptyResponse
+	}
 
-	if err := json.NewDecoder(res.Body).Decode(responseBody); err != nil {
-		if err == io.EOF {
-			return nil
+	return json.NewDecoder(bytes.NewReader(trimmed)).Decode(responseBody)
+}
+
+// do makes a single request with any extra headers given. Unsuccessful
+// responses are returned as an APIError, otherwise the caller must close the
+// response body.
+func (c *Client) do(ctx context.Context, method, url string, header http.Header) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
 		}
+	}
 
-		return err
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	req.Header.Set("x-api-version", strconv.Itoa(c.version))
+	req.Header.Set("Accept-Language", c.language.String())
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse and return general API error. A 404 will match ErrNotFound. A 304
+	// is only returned for conditional requests, which the caller handles.
+	if (res.StatusCode < 200 || res.StatusCode >= 300) && res.StatusCode != http.StatusNotModified {
+		defer res.Body.Close()
+		return nil, newAPIError(req, res)
 	}
 
-	return nil
+	return res, nil
 }
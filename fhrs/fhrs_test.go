@@ -4,7 +4,6 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"net"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 )
 
@@ -23,18 +22,11 @@ func getTestEnv() (*Client, *httptest.Server, *httprouter.Router, error) {
 
 	server.Listener = listener
 
-	client, err := NewClient()
+	client, err := NewClient(WithBaseURL("http://" + listener.Addr().String() + "/"))
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	u, err := url.Parse("http://" + listener.Addr().String() + "/")
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
-	client.baseURL = u
-
 	return client, server, router, nil
 }
 
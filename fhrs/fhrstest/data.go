@@ -0,0 +1,105 @@
+package fhrstest
+
+import (
+	"encoding/json"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+)
+
+// Data is the content served by a Server.
+//
+// Reference lists which are nil are filled with the values the API returns,
+// so usually only Establishments and Authorities need to be given. Data can
+// also be loaded from a JSON fixture file with LoadData, in which each field
+// is a list keyed by its name in lower camel case, such as "establishments".
+//
+// Establishment.LocalAuthorityCode is matched against
+// Authority.LocalAuthorityIDCode when searching by local authority.
+type Data struct {
+	Establishments   []fhrs.Establishment        `json:"establishments"`
+	Authorities      []fhrs.Authority            `json:"authorities"`
+	BusinessTypes    []fhrs.BusinessType         `json:"businessTypes"`
+	Countries        []fhrs.Country              `json:"countries"`
+	Regions          []fhrs.Region               `json:"regions"`
+	Ratings          []fhrs.Rating               `json:"ratings"`
+	SchemeTypes      []fhrs.SchemeTypeDetail     `json:"schemeTypes"`
+	SortOptions      []fhrs.SortOptionDetail     `json:"sortOptions"`
+	RatingOperators  []fhrs.RatingOperatorDetail `json:"ratingOperators"`
+	ScoreDescriptors []fhrs.ScoreDescriptor      `json:"scoreDescriptors"`
+}
+
+// LoadData reads Data from a JSON fixture file.
+func LoadData(path string) (Data, error) {
+	var data Data
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return data, err
+	}
+
+	if err := json.Unmarshal(b, &data); err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+// withDefaults returns a copy of d with the default reference lists in place of
+// any which are nil.
+func (d Data) withDefaults() Data {
+	if d.Ratings == nil {
+		d.Ratings = defaultRatings()
+	}
+
+	if d.SchemeTypes == nil {
+		d.SchemeTypes = []fhrs.SchemeTypeDetail{
+			{SchemeTypeID: 1, SchemeTypeName: "Food Hygiene Rating Scheme", SchemeTypeKey: fhrs.SchemeTypeFHRS},
+			{SchemeTypeID: 2, SchemeTypeName: "Food Hygiene Information Scheme", SchemeTypeKey: fhrs.SchemeTypeFHIS},
+		}
+	}
+
+	if d.SortOptions == nil {
+		d.SortOptions = []fhrs.SortOptionDetail{
+			{SortOptionID: 1, SortOptionName: "Relevance", SortOptionKey: fhrs.SortOptionRelevance},
+			{SortOptionID: 2, SortOptionName: "Rating (Highest - Lowest)", SortOptionKey: fhrs.SortOptionRatingHighest},
+			{SortOptionID: 3, SortOptionName: "Rating (Lowest - Highest)", SortOptionKey: fhrs.SortOptionRatingLowest},
+			{SortOptionID: 4, SortOptionName: "Name (A to Z)", SortOptionKey: fhrs.SortOptionName},
+			{SortOptionID: 5, SortOptionName: "Name (Z to A)", SortOptionKey: fhrs.SortOptionNameDesc},
+			{SortOptionID: 6, SortOptionName: "Distance", SortOptionKey: fhrs.SortOptionDistance},
+		}
+	}
+
+	if d.RatingOperators == nil {
+		d.RatingOperators = []fhrs.RatingOperatorDetail{
+			{RatingOperatorID: 1, RatingOperatorName: "Less than or equal to", RatingOperatorKey: fhrs.RatingOperatorLessThanOrEqual},
+			{RatingOperatorID: 2, RatingOperatorName: "Equal to", RatingOperatorKey: fhrs.RatingOperatorEqual},
+			{RatingOperatorID: 3, RatingOperatorName: "Greater than or equal to", RatingOperatorKey: fhrs.RatingOperatorGreaterThanOrEqual},
+		}
+	}
+
+	return d
+}
+
+func defaultRatings() []fhrs.Rating {
+	ratings := []fhrs.Rating{
+		{RatingName: "5", RatingKey: "fhrs_5_en-gb", RatingKeyName: "5", SchemeTypeID: 1},
+		{RatingName: "4", RatingKey: "fhrs_4_en-gb", RatingKeyName: "4", SchemeTypeID: 1},
+		{RatingName: "3", RatingKey: "fhrs_3_en-gb", RatingKeyName: "3", SchemeTypeID: 1},
+		{RatingName: "2", RatingKey: "fhrs_2_en-gb", RatingKeyName: "2", SchemeTypeID: 1},
+		{RatingName: "1", RatingKey: "fhrs_1_en-gb", RatingKeyName: "1", SchemeTypeID: 1},
+		{RatingName: "0", RatingKey: "fhrs_0_en-gb", RatingKeyName: "0", SchemeTypeID: 1},
+		{RatingName: "AwaitingInspection", RatingKey: "fhrs_awaitinginspection_en-gb", RatingKeyName: "Awaiting Inspection", SchemeTypeID: 1},
+		{RatingName: "Exempt", RatingKey: "fhrs_exempt_en-gb", RatingKeyName: "Exempt", SchemeTypeID: 1},
+		{RatingName: "Pass and Eat Safe", RatingKey: "fhis_pass_and_eat_safe_en-gb", RatingKeyName: "Pass and Eat Safe", SchemeTypeID: 2},
+		{RatingName: "Pass", RatingKey: "fhis_pass_en-gb", RatingKeyName: "Pass", SchemeTypeID: 2},
+		{RatingName: "Improvement Required", RatingKey: "fhis_improvement_required_en-gb", RatingKeyName: "Improvement Required", SchemeTypeID: 2},
+		{RatingName: "Awaiting Inspection", RatingKey: "fhis_awaiting_inspection_en-gb", RatingKeyName: "Awaiting Inspection", SchemeTypeID: 2},
+		{RatingName: "Exempt", RatingKey: "fhis_exempt_en-gb", RatingKeyName: "Exempt", SchemeTypeID: 2},
+	}
+
+	for i := range ratings {
+		ratings[i].RatingID = i + 1
+	}
+
+	return ratings
+}
@@ -0,0 +1,40 @@
+package fhrstest
+
+import (
+	"testing"
+)
+
+func TestLoadData(t *testing.T) {
+	data, err := LoadData("testdata/data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data.Establishments) != 1 || data.Establishments[0].BusinessName != "The Pizza Place" {
+		t.Errorf("Unexpected establishments %+v", data.Establishments)
+	}
+
+	if data.Establishments[0].RatingDate.IsZero() {
+		t.Error("Expected the rating date to be parsed")
+	}
+
+	if len(data.Authorities) != 1 || data.Authorities[0].LocalAuthorityIDCode != "876" {
+		t.Errorf("Unexpected authorities %+v", data.Authorities)
+	}
+
+	if _, err := LoadData("testdata/missing.json"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	d := Data{}.withDefaults()
+
+	if len(d.Ratings) == 0 || len(d.SchemeTypes) != 2 || len(d.SortOptions) != 6 || len(d.RatingOperators) != 3 {
+		t.Errorf("Expected default reference lists but got %+v", d)
+	}
+
+	if d.Establishments != nil || d.Authorities != nil {
+		t.Error("Expected no default establishments or authorities")
+	}
+}
@@ -0,0 +1,287 @@
+package fhrstest
+
+import (
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault is a misbehaviour injected into a response. It may answer the request
+// itself or call next, which serves the real response, and change what it
+// writes. Params holds the values of the route's named parameters.
+type Fault func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler)
+
+// FaultServer is a local server which injects faults into the responses of
+// another handler, usually a Server, for testing how code copes with a
+// misbehaving API.
+//
+// Faults are scripted per route, using httprouter patterns such as
+// "/Establishments/:id", and per call:
+//
+//	fake := fhrstest.NewServer(data)
+//	defer fake.Close()
+//
+//	faults := fhrstest.NewFaultServer(fake)
+//	defer faults.Close()
+//
+//	// Fail twice, then succeed.
+//	faults.Script("/Establishments/:id", fhrstest.Unavailable(), fhrstest.RateLimited(time.Second))
+//
+//	client, err := faults.Client(fhrs.WithRetryPolicy(fhrs.DefaultRetryPolicy))
+//
+// Requests to routes without faults are passed straight to the handler. Only
+// GET routes can be scripted, and as with httprouter a route with a named
+// parameter cannot be scripted alongside a fixed route in the same position,
+// such as "/Establishments/basic".
+type FaultServer struct {
+	URL string // Base URL of the form http://ipaddr:port/ with a trailing slash.
+
+	server *httptest.Server
+	next   http.Handler
+	mu     sync.Mutex
+	router *httprouter.Router
+	routes map[string]*script
+}
+
+// script is the faults for a route.
+type script struct {
+	faults []Fault // Injected into the call with the same index.
+	always Fault   // Injected into calls after faults run out, if set.
+	calls  int
+}
+
+// NewFaultServer starts and returns a FaultServer passing requests to next.
+// The caller should call Close when finished.
+func NewFaultServer(next http.Handler) *FaultServer {
+	if next == nil {
+		next = http.NotFoundHandler()
+	}
+
+	s := &FaultServer{
+		next:   next,
+		router: httprouter.New(),
+		routes: make(map[string]*script),
+	}
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL + "/"
+	return s
+}
+
+// Close shuts down the server.
+func (s *FaultServer) Close() {
+	s.server.Close()
+}
+
+// Client returns a client using the server. Options are applied after the
+// base URL is set.
+func (s *FaultServer) Client(opts ...fhrs.Option) (*fhrs.Client, error) {
+	return fhrs.NewClient(append([]fhrs.Option{fhrs.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// Script injects faults into successive calls to route, the first fault into
+// the next call and so on. A nil Fault lets its call through. Calls after the
+// faults run out get the fault set by Always, if any. Scripting a route again
+// replaces its faults and resets its count of calls.
+func (s *FaultServer) Script(route string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc := s.route(route)
+	sc.faults = faults
+	sc.calls = 0
+}
+
+// Always injects fault into every call to route once any scripted faults have
+// run out. A nil Fault removes it.
+func (s *FaultServer) Always(route string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.route(route).always = fault
+}
+
+// Calls returns how many requests have been made to route since it was
+// scripted.
+func (s *FaultServer) Calls(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sc, ok := s.routes[route]; ok {
+		return sc.calls
+	}
+
+	return 0
+}
+
+// route returns the script for route, registering it with the router the
+// first time. It must be called with s.mu held.
+func (s *FaultServer) route(route string) *script {
+	if sc, ok := s.routes[route]; ok {
+		return sc
+	}
+
+	sc := &script{}
+	s.routes[route] = sc
+
+	s.router.GET(route, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		s.mu.Lock()
+		var fault Fault
+		if sc.calls < len(sc.faults) {
+			fault = sc.faults[sc.calls]
+		} else {
+			fault = sc.always
+		}
+		sc.calls++
+		s.mu.Unlock()
+
+		if fault == nil {
+			s.next.ServeHTTP(w, r)
+			return
+		}
+
+		fault(w, r, p, s.next)
+	})
+
+	return sc
+}
+
+// ServeHTTP injects any faults scripted for the request's route.
+func (s *FaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The router can't be changed while it is serving, so look up the
+	// handler under the lock but call it after.
+	s.mu.Lock()
+	handle, p, _ := s.router.Lookup(r.Method, r.URL.Path)
+	s.mu.Unlock()
+
+	if handle == nil {
+		s.next.ServeHTTP(w, r)
+		return
+	}
+
+	handle(w, r, p)
+}
+
+// Status returns a Fault answering with the given status, Content-Type and
+// body. An empty contentType sends no Content-Type header.
+func Status(code int, contentType, body string) Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		if contentType == "" {
+			// Stop net/http from sniffing one.
+			w.Header()["Content-Type"] = nil
+		} else {
+			w.Header().Set("Content-Type", contentType)
+		}
+
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}
+}
+
+// ServerError returns a Fault answering 500 with an HTML page, as the API's
+// web server does when it fails.
+func ServerError() Fault {
+	return Status(http.StatusInternalServerError, fhrs.ContentTypeHTML, htmlError(http.StatusInternalServerError))
+}
+
+// Unavailable returns a Fault answering 503 with an HTML page.
+func Unavailable() Fault {
+	return Status(http.StatusServiceUnavailable, fhrs.ContentTypeHTML, htmlError(http.StatusServiceUnavailable))
+}
+
+// RateLimited returns a Fault answering 429 with a Retry-After header of
+// retryAfter, rounded up to whole seconds.
+func RateLimited(retryAfter time.Duration) Fault {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		writeError(w, http.StatusTooManyRequests, "Rate limit exceeded.")
+	}
+}
+
+// NotFound returns a Fault answering 404 as the API does.
+func NotFound() Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+	}
+}
+
+// NotFoundFor returns a Fault answering 404 when the route parameter param
+// has one of the given values, and passing other calls through. For example
+// NotFoundFor("id", "82940") on "/Establishments/:id" hides one establishment.
+func NotFoundFor(param string, values ...string) Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		for _, v := range values {
+			if p.ByName(param) == v {
+				NotFound()(w, r, p, next)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
+
+// Slow returns a Fault which waits for d before passing the call through, or
+// gives up if the client goes away first.
+func Slow(d time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		t := time.NewTimer(d)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+// Truncated returns a Fault which sends only the first half of the real
+// response body, so that JSON fails to decode.
+func Truncated() Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		rec := serve(next, r)
+		copyHeader(w.Header(), rec.Header())
+		w.Header().Del("Content-Length")
+		w.WriteHeader(rec.Code)
+
+		body := rec.Body.Bytes()
+		w.Write(body[:len(body)/2])
+	}
+}
+
+// NoContentType returns a Fault which passes the call through but removes the
+// Content-Type header from the response.
+func NoContentType() Fault {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params, next http.Handler) {
+		rec := serve(next, r)
+		copyHeader(w.Header(), rec.Header())
+		w.Header()["Content-Type"] = nil
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	}
+}
+
+// serve records the response of next to r.
+func serve(next http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	next.ServeHTTP(rec, r)
+	return rec
+}
+
+func copyHeader(dst, src http.Header) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+func htmlError(code int) string {
+	text := strconv.Itoa(code) + " " + http.StatusText(code)
+	return "<!DOCTYPE html><html><head><title>" + text + "</title></head><body><h1>" + text + "</h1></body></html>"
+}
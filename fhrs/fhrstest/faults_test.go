@@ -0,0 +1,180 @@
+package fhrstest
+
+import (
+	"errors"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func getFaultEnv(t *testing.T, opts ...fhrs.Option) (*FaultServer, *fhrs.Client, func()) {
+	fake := NewServer(testData())
+	faults := NewFaultServer(fake)
+
+	client, err := faults.Client(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return faults, client, func() {
+		faults.Close()
+		fake.Close()
+	}
+}
+
+func TestFaultServer_PassThrough(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Script("/Ratings", nil)
+
+	if _, err := client.Establishments.GetByID("1"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := client.Ratings.Get(); err != nil {
+		t.Error(err)
+	}
+
+	if calls := faults.Calls("/Ratings"); calls != 1 {
+		t.Errorf("Expected 1 call but got %d", calls)
+	}
+}
+
+func TestFaultServer_Script(t *testing.T) {
+	var retries []int
+
+	faults, client, cleanup := getFaultEnv(t, fhrs.WithRetryPolicy(fhrs.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		OnRetry: func(e fhrs.RetryEvent) {
+			var apiErr fhrs.APIError
+			if errors.As(e.Err, &apiErr) {
+				retries = append(retries, apiErr.StatusCode)
+			}
+		},
+	}))
+	defer cleanup()
+
+	faults.Script("/Establishments/:id", Unavailable(), Status(http.StatusGatewayTimeout, "", ""))
+
+	e, err := client.Establishments.GetByID("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.FHRSID != 1 {
+		t.Errorf("Expected establishment 1 but got %d", e.FHRSID)
+	}
+
+	if len(retries) != 2 || retries[0] != http.StatusServiceUnavailable || retries[1] != http.StatusGatewayTimeout {
+		t.Errorf("Expected retries after 503 and 504 but got %v", retries)
+	}
+
+	if calls := faults.Calls("/Establishments/:id"); calls != 3 {
+		t.Errorf("Expected 3 calls but got %d", calls)
+	}
+}
+
+func TestFaultServer_Always(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Always("/Authorities", ServerError())
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Authorities.Get()
+
+		var apiErr fhrs.APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsServerError() {
+			t.Fatalf("Expected a server error but got %v", err)
+		}
+
+		if apiErr.Message == "" {
+			t.Error("Expected the HTML error page to give a message")
+		}
+	}
+
+	faults.Always("/Authorities", nil)
+
+	if _, err := client.Authorities.Get(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Script("/Ratings", RateLimited(1500*time.Millisecond))
+
+	_, err := client.Ratings.Get()
+
+	var apiErr fhrs.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsRateLimited() {
+		t.Fatalf("Expected a rate limited error but got %v", err)
+	}
+
+	if ra := apiErr.Header.Get("Retry-After"); ra != "2" {
+		t.Errorf("Expected Retry-After to be 2 but got %q", ra)
+	}
+}
+
+func TestNotFoundFor(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Always("/Establishments/:id", NotFoundFor("id", "2"))
+
+	if _, err := client.Establishments.GetByID("1"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := client.Establishments.GetByID("2"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
+
+func TestSlow(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t, fhrs.WithTimeout(50*time.Millisecond))
+	defer cleanup()
+
+	faults.Script("/Ratings", Slow(time.Second))
+
+	if _, err := client.Ratings.Get(); err == nil {
+		t.Error("Expected a timeout")
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	faults, client, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Script("/Establishments/:id", Truncated())
+
+	if _, err := client.Establishments.GetByID("1"); err == nil {
+		t.Error("Expected truncated JSON to fail to decode")
+	}
+}
+
+func TestNoContentType(t *testing.T) {
+	faults, _, cleanup := getFaultEnv(t)
+	defer cleanup()
+
+	faults.Script("/Establishments/:id", NoContentType())
+
+	res, err := http.Get(faults.URL + "Establishments/99")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404 but got %d", res.StatusCode)
+	}
+
+	if ct, ok := res.Header["Content-Type"]; ok {
+		t.Errorf("Expected no Content-Type but got %q", ct)
+	}
+}
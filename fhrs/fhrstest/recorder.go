@@ -0,0 +1,297 @@
+package fhrstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mode is how a Recorder uses its cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette without using the
+	// network. A request with no recorded interaction fails.
+	ModeReplay Mode = iota
+
+	// ModeRecord makes every request over the network and records it,
+	// replacing any existing cassette.
+	ModeRecord
+
+	// ModeRecordMissing answers requests from the cassette where possible and
+	// records the rest.
+	ModeRecordMissing
+)
+
+// scrubbed replaces the values of scrubbed query parameters.
+const scrubbed = "REDACTED"
+
+// Cassette is a set of recorded HTTP interactions, saved as JSON.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request kept in a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+}
+
+// RecordedResponse is the part of a response kept in a cassette. Body is held
+// as text, which suits the API's JSON and HTML bodies.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper which records interactions with the API to
+// a cassette file and replays them, so tests can run against real responses
+// without network access.
+//
+//	rec, err := fhrstest.NewRecorder("testdata/establishment.json", fhrstest.ModeReplay)
+//	if err != nil {
+//		// Handle err
+//	}
+//
+//	client, err := rec.Client()
+//
+// Requests are matched on method, path, query and the headers in
+// MatchHeaders, but not host, so a cassette recorded against the API can be
+// replayed against any base URL. Matching requests are answered in the order
+// they were recorded, repeating the last once all have been used.
+//
+// Headers in ScrubHeaders and query parameters in ScrubQuery are removed from
+// cassettes so that secrets are not committed with tests. The fields should
+// be set before the Recorder is first used.
+type Recorder struct {
+	Transport    http.RoundTripper // Used to record. Defaults to http.DefaultTransport.
+	MatchHeaders []string          // Request headers which must match, such as Accept-Language.
+	ScrubHeaders []string          // Request and response headers not to record.
+	ScrubQuery   []string          // Query parameters whose values are not recorded.
+
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder using the cassette at path. The cassette must
+// exist for ModeReplay, and is created as needed when recording.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		MatchHeaders: []string{"Accept-Language", "x-api-version"},
+		ScrubHeaders: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+		ScrubQuery:   []string{"key", "api_key", "apikey", "token", "access_token"},
+		path:         path,
+		mode:         mode,
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err) && mode == ModeRecordMissing:
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("Invalid cassette %s: %v", path, err)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Client returns a client whose requests go through the Recorder. Options are
+// applied after the HTTP client is set.
+func (r *Recorder) Client(opts ...fhrs.Option) (*fhrs.Client, error) {
+	return fhrs.NewClient(append([]fhrs.Option{fhrs.WithHTTPClient(&http.Client{Transport: r})}, opts...)...)
+}
+
+// RoundTrip answers req from the cassette or the network, depending on the
+// mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := r.recordRequest(req)
+
+	r.mu.Lock()
+	i := r.match(recorded)
+	if i >= 0 && r.mode != ModeRecord {
+		r.used[i] = true
+		interaction := r.cassette.Interactions[i]
+		r.mu.Unlock()
+
+		return replay(req, interaction.Response), nil
+	}
+	r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		return nil, fmt.Errorf("No recorded interaction for %s %s in %s", req.Method, recorded.URL, r.path)
+	}
+
+	return r.record(req, recorded)
+}
+
+// record makes req over the network and saves the interaction.
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     r.scrubHeader(res.Header),
+			Body:       string(body),
+		},
+	})
+	r.used = append(r.used, true)
+
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// save writes the cassette to its file.
+func (r *Recorder) save() error {
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, b, 0644)
+}
+
+// match returns the index of the interaction recorded for req, preferring one
+// not yet used, or -1 if there is none.
+func (r *Recorder) match(req RecordedRequest) int {
+	last := -1
+
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matches(interaction.Request, req) {
+			continue
+		}
+
+		if !r.used[i] {
+			return i
+		}
+
+		last = i
+	}
+
+	return last
+}
+
+func (r *Recorder) matches(a, b RecordedRequest) bool {
+	if a.Method != b.Method {
+		return false
+	}
+
+	ua, err := url.Parse(a.URL)
+	if err != nil {
+		return false
+	}
+
+	ub, err := url.Parse(b.URL)
+	if err != nil {
+		return false
+	}
+
+	if ua.Path != ub.Path || ua.Query().Encode() != ub.Query().Encode() {
+		return false
+	}
+
+	for _, h := range r.MatchHeaders {
+		if a.Header.Get(h) != b.Header.Get(h) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordRequest returns req as it is kept in a cassette.
+func (r *Recorder) recordRequest(req *http.Request) RecordedRequest {
+	u := *req.URL
+	u.User = nil
+
+	q := u.Query()
+	for key := range q {
+		for _, s := range r.ScrubQuery {
+			if strings.EqualFold(key, s) {
+				q.Set(key, scrubbed)
+			}
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	return RecordedRequest{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: r.scrubHeader(req.Header),
+	}
+}
+
+// scrubHeader returns a copy of h without the headers in ScrubHeaders.
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, s := range r.ScrubHeaders {
+		h.Del(s)
+	}
+
+	return h
+}
+
+// replay builds the response to req from a recording.
+func replay(req *http.Request, recorded RecordedResponse) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,193 @@
+package fhrstest
+
+import (
+	"errors"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "fhrstest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+func TestRecorder(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	server := NewServer(testData())
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := rec.Client(fhrs.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := client.Establishments.GetByID("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Establishments.GetByID("99"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound but got %v", err)
+	}
+
+	server.Close()
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The host differs from the recording but should still match.
+	client, err = rec.Client(fhrs.WithBaseURL("http://fhrs.invalid/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := client.Establishments.GetByID("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed.BusinessName != recorded.BusinessName {
+		t.Errorf("Expected %s but got %s", recorded.BusinessName, replayed.BusinessName)
+	}
+
+	if _, err := client.Establishments.GetByID("99"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Errorf("Expected the recorded ErrNotFound but got %v", err)
+	}
+
+	if _, err := client.Establishments.GetByID("2"); err == nil || !strings.Contains(err.Error(), "No recorded interaction") {
+		t.Errorf("Expected an unrecorded request to fail but got %v", err)
+	}
+
+	// The language is part of the match.
+	client.SetLanguage(fhrs.LanguageCymraeg)
+	if _, err := client.Establishments.GetByID("1"); err == nil {
+		t.Error("Expected a request in another language not to match")
+	}
+}
+
+func TestRecorder_Scrub(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	server := NewServer(testData())
+	defer server.Close()
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"Ratings?key=secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+
+	res, err := (&http.Client{Transport: rec}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), "secret") {
+		t.Errorf("Expected secrets to be scrubbed but got:\n%s", b)
+	}
+
+	// A replayed request with a different secret still matches.
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "http://fhrs.invalid/Ratings?key=other", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = (&http.Client{Transport: rec}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 but got %d", res.StatusCode)
+	}
+}
+
+func TestRecorder_RecordMissing(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	server := NewServer(testData())
+	defer server.Close()
+
+	rec, err := NewRecorder(path, ModeRecordMissing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := rec.Client(fhrs.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Establishments.GetByID("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second request is replayed, so does not see the change.
+	server.Update(func(d *Data) {
+		d.Establishments[0].BusinessName = "Renamed"
+	})
+
+	e, err := client.Establishments.GetByID("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.BusinessName != "The Pizza Place" {
+		t.Errorf("Expected the recorded name but got %s", e.BusinessName)
+	}
+
+	if _, err := client.Establishments.GetByID("2"); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.cassette.Interactions) != 2 {
+		t.Errorf("Expected 2 interactions but got %d", len(rec.cassette.Interactions))
+	}
+}
+
+func TestNewRecorder_Missing(t *testing.T) {
+	if _, err := NewRecorder("testdata/missing.json", ModeReplay); err == nil {
+		t.Error("Expected an error for a missing cassette")
+	}
+}
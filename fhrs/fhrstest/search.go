@@ -0,0 +1,237 @@
+package fhrstest
+
+import (
+	"fmt"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// earthRadius is the mean radius of the Earth in miles, which is the unit of
+// maxDistanceLimit and Establishment.Distance.
+const earthRadius = 3958.8
+
+// query is a parsed establishment search.
+type query struct {
+	name            string
+	address         string
+	location        bool
+	latitude        float64
+	longitude       float64
+	maxDistance     float64
+	businessTypeID  int
+	schemeType      fhrs.SchemeType
+	rating          *fhrs.RatingValue
+	operator        fhrs.RatingOperator
+	filterAuthority bool
+	authorityCode   string // Empty if the authority is unknown, matching nothing.
+	sortOption      fhrs.SortOption
+	pageNumber      int
+	pageSize        int
+}
+
+// parseQuery parses the query string of a search in the same way as the API,
+// returning an error for values which cannot be parsed.
+func parseQuery(d *Data, v url.Values) (*query, error) {
+	q := &query{
+		name:       strings.ToLower(strings.TrimSpace(v.Get("name"))),
+		address:    strings.ToLower(strings.TrimSpace(v.Get("address"))),
+		schemeType: fhrs.SchemeType(v.Get("schemeTypeKey")),
+		operator:   fhrs.RatingOperatorEqual,
+		pageNumber: 1,
+		pageSize:   fhrs.MaxPageSize,
+	}
+
+	var err error
+	number := func(key string) (float64, bool) {
+		s := v.Get(key)
+		if s == "" || err != nil {
+			return 0, false
+		}
+
+		var f float64
+		if f, err = strconv.ParseFloat(s, 64); err != nil {
+			err = fmt.Errorf("The value '%s' is not valid for %s.", s, key)
+			return 0, false
+		}
+
+		return f, true
+	}
+
+	latitude, hasLatitude := number("latitude")
+	longitude, hasLongitude := number("longitude")
+	maxDistance, hasMaxDistance := number("maxDistanceLimit")
+	businessTypeID, _ := number("businessTypeId")
+	pageNumber, hasPageNumber := number("pageNumber")
+	pageSize, hasPageSize := number("pageSize")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasLatitude && hasLongitude {
+		q.location = true
+		q.latitude, q.longitude = latitude, longitude
+		q.maxDistance = math.Inf(1)
+		if hasMaxDistance {
+			q.maxDistance = maxDistance
+		}
+	}
+
+	q.businessTypeID = int(businessTypeID)
+
+	if hasPageNumber && pageNumber >= 1 {
+		q.pageNumber = int(pageNumber)
+	}
+
+	if hasPageSize && pageSize >= 1 && pageSize <= fhrs.MaxPageSize {
+		q.pageSize = int(pageSize)
+	}
+
+	if key := v.Get("ratingKey"); key != "" {
+		rating := fhrs.ParseRatingValue(key, key)
+		q.rating = &rating
+
+		if op := v.Get("ratingOperatorKey"); op != "" {
+			if q.operator, err = fhrs.ParseRatingOperator(op); err != nil {
+				return nil, fmt.Errorf("The value '%s' is not valid for ratingOperatorKey.", op)
+			}
+		}
+	}
+
+	if id := v.Get("localAuthorityId"); id != "" {
+		q.filterAuthority = true
+		for _, a := range d.Authorities {
+			if strconv.Itoa(a.LocalAuthorityID) == id {
+				q.authorityCode = a.LocalAuthorityIDCode
+			}
+		}
+	}
+
+	q.sortOption, _ = fhrs.ParseSortOption(v.Get("sortOptionKey"))
+
+	return q, nil
+}
+
+// search returns the establishments matching q, sorted and with Distance set
+// if a location was given, before paging.
+func (q *query) search(establishments []fhrs.Establishment) []fhrs.Establishment {
+	var matches []fhrs.Establishment
+
+	for _, e := range establishments {
+		if q.location {
+			latitude, longitude, ok := e.Geocode.Coordinates()
+			if !ok {
+				continue
+			}
+
+			distance := haversine(q.latitude, q.longitude, latitude, longitude)
+			if distance > q.maxDistance {
+				continue
+			}
+
+			e.Distance = &distance
+		}
+
+		if q.matches(e) {
+			matches = append(matches, e)
+		}
+	}
+
+	q.sort(matches)
+
+	return matches
+}
+
+func (q *query) matches(e fhrs.Establishment) bool {
+	if q.name != "" && !strings.Contains(strings.ToLower(e.BusinessName), q.name) {
+		return false
+	}
+
+	if q.address != "" && !strings.Contains(strings.ToLower(address(e)), q.address) {
+		return false
+	}
+
+	if q.businessTypeID != 0 && e.BusinessTypeID != q.businessTypeID {
+		return false
+	}
+
+	if q.schemeType != "" && !strings.EqualFold(e.SchemeType, string(q.schemeType)) {
+		return false
+	}
+
+	if q.filterAuthority && (q.authorityCode == "" || e.LocalAuthorityCode != q.authorityCode) {
+		return false
+	}
+
+	if q.rating != nil && !q.matchesRating(e.Rating()) {
+		return false
+	}
+
+	return true
+}
+
+func (q *query) matchesRating(r fhrs.RatingValue) bool {
+	if !q.rating.Rated() || q.operator == fhrs.RatingOperatorEqual {
+		return r.Status == q.rating.Status && (!r.Rated() || r.Score == q.rating.Score) &&
+			(q.rating.Scheme == "" || r.Scheme == q.rating.Scheme)
+	}
+
+	if !r.Rated() || r.Scheme != q.rating.Scheme {
+		return false
+	}
+
+	if q.operator == fhrs.RatingOperatorLessThanOrEqual {
+		return r.Score <= q.rating.Score
+	}
+
+	return r.Score >= q.rating.Score
+}
+
+func (q *query) sort(establishments []fhrs.Establishment) {
+	var less func(a, b fhrs.Establishment) bool
+
+	switch q.sortOption {
+	case fhrs.SortOptionRatingHighest:
+		less = func(a, b fhrs.Establishment) bool { return b.Rating().Less(a.Rating()) }
+	case fhrs.SortOptionRatingLowest:
+		less = func(a, b fhrs.Establishment) bool { return a.Rating().Less(b.Rating()) }
+	case fhrs.SortOptionName:
+		less = func(a, b fhrs.Establishment) bool { return a.BusinessName < b.BusinessName }
+	case fhrs.SortOptionNameDesc:
+		less = func(a, b fhrs.Establishment) bool { return a.BusinessName > b.BusinessName }
+	case fhrs.SortOptionDistance:
+		if !q.location {
+			return
+		}
+
+		less = func(a, b fhrs.Establishment) bool { return *a.Distance < *b.Distance }
+	default:
+		return
+	}
+
+	sort.SliceStable(establishments, func(i, j int) bool {
+		return less(establishments[i], establishments[j])
+	})
+}
+
+// address returns the address lines and post code of e on one line.
+func address(e fhrs.Establishment) string {
+	return strings.Join([]string{e.AddressLine1, e.AddressLine2, e.AddressLine3, e.AddressLine4, e.PostCode}, " ")
+}
+
+// haversine returns the distance in miles between two points.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,133 @@
+package fhrstest
+
+import (
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"reflect"
+	"testing"
+)
+
+func fhrsIDs(establishments []fhrs.Establishment) []int {
+	ids := []int{}
+	for _, e := range establishments {
+		ids = append(ids, e.FHRSID)
+	}
+
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	cases := []struct {
+		name     string
+		search   *fhrs.SearchBuilder
+		expected []int
+	}{
+		{"All", fhrs.NewSearch(), []int{1, 2, 3, 4}},
+		{"Name", fhrs.NewSearch().Name("PIZZA"), []int{1}},
+		{"Address", fhrs.NewSearch().Address("po"), []int{1, 2}},
+		{"BusinessType", fhrs.NewSearch().BusinessType(7), []int{3}},
+		{"Scheme", fhrs.NewSearch().Scheme(fhrs.SchemeTypeFHIS), []int{4}},
+		{"Authority", fhrs.NewSearch().InAuthority(1), []int{1, 2}},
+		{"UnknownAuthority", fhrs.NewSearch().InAuthority(99), []int{}},
+		{"RatingEquals", fhrs.NewSearch().RatingEquals(3), []int{2}},
+		{"RatingAtLeast", fhrs.NewSearch().RatingAtLeast(4), []int{1}},
+		{"RatingAtMost", fhrs.NewSearch().RatingAtMost(4), []int{2}},
+		{"RatingKey", fhrs.NewSearch().Rating("Exempt"), []int{3}},
+		{"SortByName", fhrs.NewSearch().SortBy(fhrs.SortOptionName), []int{2, 4, 3, 1}},
+		{"SortByNameDesc", fhrs.NewSearch().SortBy(fhrs.SortOptionNameDesc), []int{1, 3, 4, 2}},
+		{"SortByRatingHighest", fhrs.NewSearch().Scheme(fhrs.SchemeTypeFHRS).SortBy(fhrs.SortOptionRatingHighest), []int{1, 2, 3}},
+		{"SortByRatingLowest", fhrs.NewSearch().Scheme(fhrs.SchemeTypeFHRS).SortBy(fhrs.SortOptionRatingLowest), []int{3, 2, 1}},
+		{"Near", fhrs.NewSearch().Near(50.8198, -1.0880, 2).SortBy(fhrs.SortOptionDistance), []int{2, 1}},
+		{"Page", fhrs.NewSearch().Page(2, 3), []int{4}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			params, err := c.search.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.Establishments.Search(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ids := fhrsIDs(actual.Establishments); !reflect.DeepEqual(c.expected, ids) {
+				t.Errorf("Expected %v but got %v", c.expected, ids)
+			}
+		})
+	}
+}
+
+func TestSearch_Distance(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	params, err := fhrs.NewSearch().Near(50.7984, -1.0916, 5).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := client.Establishments.Search(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual.Establishments) != 2 {
+		t.Fatalf("Expected 2 establishments but got %d", len(actual.Establishments))
+	}
+
+	for _, e := range actual.Establishments {
+		if e.Distance == nil {
+			t.Fatalf("Expected a distance for %d", e.FHRSID)
+		}
+	}
+
+	if d := *actual.Establishments[0].Distance; d != 0 {
+		t.Errorf("Expected a distance of 0 but got %v", d)
+	}
+
+	// Roughly 1.5 miles between the two.
+	if d := *actual.Establishments[1].Distance; d < 1.4 || d > 1.6 {
+		t.Errorf("Expected a distance of about 1.5 miles but got %v", d)
+	}
+}
+
+func TestSearch_Meta(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	params, err := fhrs.NewSearch().Page(1, 3).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := client.Establishments.Search(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fhrs.Meta{
+		DataSource: "API",
+		ItemCount:  3,
+		Returncode: "OK",
+		TotalCount: 4,
+		TotalPages: 2,
+		PageSize:   3,
+		PageNumber: 1,
+	}
+
+	if !reflect.DeepEqual(expected, actual.Meta) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual.Meta)
+	}
+}
+
+func TestHaversine(t *testing.T) {
+	// Portsmouth to Brighton is about 42 miles in a straight line.
+	if d := haversine(50.7984, -1.0916, 50.8225, -0.1372); d < 41 || d > 43 {
+		t.Errorf("Expected about 42 miles but got %v", d)
+	}
+}
@@ -0,0 +1,395 @@
+// Package fhrstest provides a fake FHRS API server for testing code which uses
+// the fhrs package.
+//
+//	server := fhrstest.NewServer(fhrstest.Data{
+//		Establishments: []fhrs.Establishment{
+//			{FHRSID: 82940, BusinessName: "The Pizza Place", RatingValue: "5"},
+//		},
+//	})
+//	defer server.Close()
+//
+//	client, err := server.Client()
+//	if err != nil {
+//		// Handle err
+//	}
+//
+//	est, err := client.Establishments.GetByID("82940")
+//
+// The server keeps its data in memory and answers the Establishments, Ratings
+// and reference data endpoints as the API does, including filtering, sorting,
+// paging and distances for searches. Searching by country is not supported as
+// establishments do not record their country, so countryId is ignored.
+package fhrstest
+
+import (
+	"encoding/json"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is a fake FHRS API listening on a local address.
+type Server struct {
+	URL string // Base URL of the form http://ipaddr:port/ with a trailing slash.
+
+	server *httptest.Server
+	mu     sync.RWMutex
+	data   Data
+}
+
+// NewServer starts and returns a Server serving data. The caller should call
+// Close when finished.
+func NewServer(data Data) *Server {
+	s := &Server{data: data.withDefaults()}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL + "/"
+	return s
+}
+
+// NewServerFromFile is like NewServer but loads the data from a JSON fixture
+// file, as described by Data.
+func NewServerFromFile(path string) (*Server, error) {
+	data, err := LoadData(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServer(data), nil
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client using the server. Options are applied after the
+// base URL is set.
+func (s *Server) Client(opts ...fhrs.Option) (*fhrs.Client, error) {
+	return fhrs.NewClient(append([]fhrs.Option{fhrs.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// Update calls f with the server's data, which it may change. Requests wait
+// until f returns.
+func (s *Server) Update(f func(d *Data)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(&s.data)
+	s.data = s.data.withDefaults()
+}
+
+// ServeHTTP answers a request as the API would.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "The requested resource does not support http method '"+r.Method+"'.")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	resource, args := strings.ToLower(path[0]), path[1:]
+
+	switch resource {
+	case "establishments":
+		s.establishments(w, r, args)
+	case "scoredescriptors":
+		s.scoreDescriptors(w, r)
+	default:
+		l, ok := s.data.list(resource)
+		if !ok {
+			writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+			return
+		}
+
+		serveList(w, l, args)
+	}
+}
+
+func (s *Server) establishments(w http.ResponseWriter, r *http.Request, args []string) {
+	switch {
+	case len(args) == 0:
+		s.search(w, r)
+	case strings.EqualFold(args[0], "basic"):
+		s.basicEstablishments(w, args[1:])
+	case len(args) == 1:
+		e, ok := s.establishment(args[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "No establishment found with FHRSID "+args[0]+".")
+			return
+		}
+
+		writeJSON(w, e)
+	default:
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+	}
+}
+
+// establishment returns the establishment with the given FHRSID.
+func (s *Server) establishment(id string) (fhrs.Establishment, bool) {
+	for _, e := range s.data.Establishments {
+		if strconv.Itoa(e.FHRSID) == id {
+			return e, true
+		}
+	}
+
+	return fhrs.Establishment{}, false
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(&s.data, r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	matches := q.search(s.data.Establishments)
+	start, end, meta := page(len(matches), q.pageNumber, q.pageSize)
+
+	writeJSON(w, fhrs.Establishments{
+		Establishments: append([]fhrs.Establishment{}, matches[start:end]...),
+		Meta:           meta,
+		Links:          []fhrs.Link{},
+	})
+}
+
+func (s *Server) basicEstablishments(w http.ResponseWriter, args []string) {
+	pageNumber, pageSize, ok := pageArgs(args)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+		return
+	}
+
+	start, end, meta := page(len(s.data.Establishments), pageNumber, pageSize)
+
+	basic := []fhrs.BasicEstablishment{}
+	for _, e := range s.data.Establishments[start:end] {
+		basic = append(basic, fhrs.BasicEstablishment{
+			FHRSID:                   e.FHRSID,
+			LocalAuthorityBusinessID: e.LocalAuthorityBusinessID,
+			BusinessName:             e.BusinessName,
+			RatingValue:              e.RatingValue,
+			RatingKey:                e.RatingKey,
+			RatingDate:               e.RatingDate,
+			Links:                    e.Links,
+		})
+	}
+
+	writeJSON(w, fhrs.BasicEstablishments{Establishments: basic, Meta: meta, Links: []fhrs.Link{}})
+}
+
+// scoreDescriptors returns the descriptions matching the scores of the
+// establishment given by the establishmentId parameter.
+func (s *Server) scoreDescriptors(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("establishmentId")
+
+	e, ok := s.establishment(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No establishment found with FHRSID "+id+".")
+		return
+	}
+
+	scores := map[string]*int{
+		"Hygiene":    e.Scores.Hygiene,
+		"Structural": e.Scores.Structural,
+		"Confidence": e.Scores.ConfidenceInManagement,
+	}
+
+	descriptors := []fhrs.ScoreDescriptor{}
+	for _, d := range s.data.ScoreDescriptors {
+		if score := scores[d.ScoreCategory]; score != nil && *score == d.Score {
+			descriptors = append(descriptors, d)
+		}
+	}
+
+	writeJSON(w, fhrs.ScoreDescriptors{
+		ScoreDescriptors: descriptors,
+		Meta:             fhrs.Meta{DataSource: "Lookup", ItemCount: len(descriptors), Returncode: "OK"},
+		Links:            []fhrs.Link{},
+	})
+}
+
+// list is a reference data list served by the generic endpoints.
+type list struct {
+	key   string // Name of the list in responses, such as "authorities".
+	paged bool   // Whether the list has basic, by ID and paged endpoints.
+	items []interface{}
+	ids   []int
+}
+
+func (l *list) add(id int, item interface{}) {
+	l.items = append(l.items, item)
+	l.ids = append(l.ids, id)
+}
+
+// list returns the reference list for the lower case resource name.
+func (d *Data) list(resource string) (list, bool) {
+	var l list
+
+	switch resource {
+	case "authorities":
+		l = list{key: "authorities", paged: true}
+		for _, v := range d.Authorities {
+			l.add(v.LocalAuthorityID, v)
+		}
+	case "businesstypes":
+		l = list{key: "businessTypes", paged: true}
+		for _, v := range d.BusinessTypes {
+			l.add(v.BusinessTypeID, v)
+		}
+	case "countries":
+		l = list{key: "countries", paged: true}
+		for _, v := range d.Countries {
+			l.add(v.ID, v)
+		}
+	case "regions":
+		l = list{key: "regions", paged: true}
+		for _, v := range d.Regions {
+			l.add(v.ID, v)
+		}
+	case "ratings":
+		l = list{key: "ratings"}
+		for _, v := range d.Ratings {
+			l.add(v.RatingID, v)
+		}
+	case "schemetypes":
+		l = list{key: "schemeTypes"}
+		for _, v := range d.SchemeTypes {
+			l.add(v.SchemeTypeID, v)
+		}
+	case "sortoptions":
+		l = list{key: "sortOptions"}
+		for _, v := range d.SortOptions {
+			l.add(v.SortOptionID, v)
+		}
+	case "ratingoperators":
+		l = list{key: "ratingOperator"}
+		for _, v := range d.RatingOperators {
+			l.add(v.RatingOperatorID, v)
+		}
+	default:
+		return l, false
+	}
+
+	return l, true
+}
+
+// serveList answers the list endpoints, which are /, /basic, /{id} and their
+// /{pageNumber}/{pageSize} variants.
+func serveList(w http.ResponseWriter, l list, args []string) {
+	if len(args) > 0 && !l.paged {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+		return
+	}
+
+	if len(args) > 0 && strings.EqualFold(args[0], "basic") {
+		args = args[1:]
+	} else if len(args) == 1 {
+		for i, id := range l.ids {
+			if strconv.Itoa(id) == args[0] {
+				writeJSON(w, l.items[i])
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "No item found with ID "+args[0]+".")
+		return
+	}
+
+	pageNumber, pageSize, ok := pageArgs(args)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+		return
+	}
+
+	start, end, meta := page(len(l.items), pageNumber, pageSize)
+	meta.DataSource = "Lookup"
+
+	items := l.items[start:end]
+	if items == nil {
+		items = []interface{}{}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		l.key:   items,
+		"meta":  meta,
+		"links": []fhrs.Link{},
+	})
+}
+
+// pageArgs parses the optional /{pageNumber}/{pageSize} of a path. Zero means
+// all items.
+func pageArgs(args []string) (pageNumber, pageSize int, ok bool) {
+	switch len(args) {
+	case 0:
+		return 1, 0, true
+	case 2:
+		pageNumber, err := strconv.Atoi(args[0])
+		if err != nil || pageNumber < 1 {
+			return 0, 0, false
+		}
+
+		pageSize, err := strconv.Atoi(args[1])
+		if err != nil || pageSize < 1 {
+			return 0, 0, false
+		}
+
+		return pageNumber, pageSize, true
+	}
+
+	return 0, 0, false
+}
+
+// page returns the bounds of a page of a list of n items, and its Meta. A
+// pageSize of zero returns every item on one page.
+func page(n, pageNumber, pageSize int) (start, end int, meta fhrs.Meta) {
+	if pageSize == 0 {
+		pageSize = n
+	}
+
+	totalPages := 1
+	if pageSize > 0 {
+		totalPages = (n + pageSize - 1) / pageSize
+	}
+
+	start = (pageNumber - 1) * pageSize
+	if start > n {
+		start = n
+	}
+
+	end = start + pageSize
+	if end > n {
+		end = n
+	}
+
+	return start, end, fhrs.Meta{
+		DataSource: "API",
+		ItemCount:  end - start,
+		Returncode: "OK",
+		TotalCount: n,
+		TotalPages: totalPages,
+		PageSize:   pageSize,
+		PageNumber: pageNumber,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", fhrs.ContentTypeJSON+"; charset=utf-8")
+	w.Write(b)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", fhrs.ContentTypeJSON+"; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(fhrs.ErrorResponse{Message: message})
+}
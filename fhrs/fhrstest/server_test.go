@@ -0,0 +1,273 @@
+package fhrstest
+
+import (
+	"errors"
+	"github.com/dcrichards/go-fhrs/fhrs"
+	"net/http"
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func testData() Data {
+	return Data{
+		Establishments: []fhrs.Establishment{
+			{
+				FHRSID: 1, BusinessName: "The Pizza Place", BusinessTypeID: 1, PostCode: "PO1 1AA",
+				RatingValue: "5", RatingKey: "fhrs_5_en-gb", SchemeType: "FHRS", LocalAuthorityCode: "876",
+				Geocode: fhrs.Geocode{Latitude: "50.7984", Longitude: "-1.0916"},
+				Scores:  fhrs.Scores{Hygiene: intPtr(0), Structural: intPtr(5)},
+			},
+			{
+				FHRSID: 2, BusinessName: "Burger Bar", BusinessTypeID: 1, PostCode: "PO2 2BB",
+				RatingValue: "3", RatingKey: "fhrs_3_en-gb", SchemeType: "FHRS", LocalAuthorityCode: "876",
+				Geocode: fhrs.Geocode{Latitude: "50.8198", Longitude: "-1.0880"},
+			},
+			{
+				FHRSID: 3, BusinessName: "Corner Shop", BusinessTypeID: 7, PostCode: "BN1 1AA",
+				RatingValue: "Exempt", RatingKey: "fhrs_exempt_en-gb", SchemeType: "FHRS", LocalAuthorityCode: "875",
+				Geocode: fhrs.Geocode{Latitude: "50.8225", Longitude: "-0.1372"},
+			},
+			{
+				FHRSID: 4, BusinessName: "Chippy", BusinessTypeID: 1, PostCode: "AB1 1AA",
+				RatingValue: "Pass", RatingKey: "fhis_pass_en-gb", SchemeType: "FHIS", LocalAuthorityCode: "760",
+			},
+		},
+		Authorities: []fhrs.Authority{
+			{LocalAuthorityID: 1, LocalAuthorityIDCode: "876", Name: "Portsmouth"},
+			{LocalAuthorityID: 2, LocalAuthorityIDCode: "875", Name: "Brighton and Hove"},
+			{LocalAuthorityID: 197, LocalAuthorityIDCode: "760", Name: "Aberdeen City"},
+		},
+		BusinessTypes: []fhrs.BusinessType{
+			{BusinessTypeID: 1, BusinessTypeName: "Restaurant/Cafe/Canteen"},
+			{BusinessTypeID: 7, BusinessTypeName: "Retailers - other"},
+		},
+		ScoreDescriptors: []fhrs.ScoreDescriptor{
+			{ID: 1, ScoreCategory: "Hygiene", Score: 0, Description: "Very good"},
+			{ID: 2, ScoreCategory: "Hygiene", Score: 5, Description: "Good"},
+			{ID: 3, ScoreCategory: "Structural", Score: 5, Description: "Good"},
+		},
+	}
+}
+
+func getTestEnv(t *testing.T) (*Server, *fhrs.Client) {
+	server := NewServer(testData())
+
+	client, err := server.Client()
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	return server, client
+}
+
+func TestEstablishmentsGetByID(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	e, err := client.Establishments.GetByID("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.BusinessName != "Burger Bar" {
+		t.Errorf("Expected Burger Bar but got %s", e.BusinessName)
+	}
+
+	if _, err := client.Establishments.GetByID("99"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
+
+func TestEstablishmentsBasic(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	basic, err := client.Establishments.Basic(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(basic.Establishments) != 1 || basic.Establishments[0].FHRSID != 4 {
+		t.Errorf("Expected the fourth establishment but got %+v", basic.Establishments)
+	}
+
+	if basic.Meta.TotalCount != 4 || basic.Meta.TotalPages != 2 {
+		t.Errorf("Unexpected meta %+v", basic.Meta)
+	}
+}
+
+func TestReferenceData(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	authorities, err := client.Authorities.GetPage(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(authorities.Authorities) != 2 || authorities.Meta.TotalPages != 2 {
+		t.Errorf("Unexpected authorities %+v", authorities)
+	}
+
+	authority, err := client.Authorities.GetByID("197")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if authority.Name != "Aberdeen City" {
+		t.Errorf("Expected Aberdeen City but got %s", authority.Name)
+	}
+
+	businessTypes, err := client.BusinessTypes.Basic()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(businessTypes.BusinessTypes) != 2 {
+		t.Errorf("Expected 2 business types but got %d", len(businessTypes.BusinessTypes))
+	}
+
+	countries, err := client.Countries.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if countries.Countries == nil || len(countries.Countries) != 0 {
+		t.Errorf("Expected an empty list of countries but got %+v", countries.Countries)
+	}
+
+	if _, err := client.Regions.GetByID("1"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+
+	ratings, err := client.Ratings.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ratings.Ratings) == 0 || !ratings.Ratings[0].Value().Rated() {
+		t.Errorf("Expected the default ratings but got %+v", ratings.Ratings)
+	}
+
+	operators, err := client.RatingOperators.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, o := range operators.RatingOperators {
+		if !o.RatingOperatorKey.Known() {
+			t.Errorf("Expected a known rating operator but got %s", o.RatingOperatorKey)
+		}
+	}
+
+	schemes, err := client.SchemeTypes.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(schemes.SchemeTypes) != 2 {
+		t.Errorf("Expected 2 scheme types but got %d", len(schemes.SchemeTypes))
+	}
+
+	options, err := client.SortOptions.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(options.SortOptions) != 6 {
+		t.Errorf("Expected 6 sort options but got %d", len(options.SortOptions))
+	}
+}
+
+func TestScoreDescriptors(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	descriptors, err := client.ScoreDescriptors.Get("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(descriptors.ScoreDescriptors) != 2 {
+		t.Fatalf("Expected 2 descriptors but got %+v", descriptors.ScoreDescriptors)
+	}
+
+	for _, d := range descriptors.ScoreDescriptors {
+		if d.ID == 2 {
+			t.Errorf("Expected the descriptor for another score not to be returned")
+		}
+	}
+
+	if _, err := client.ScoreDescriptors.Get("99"); !errors.Is(err, fhrs.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	server, client := getTestEnv(t)
+	defer server.Close()
+
+	server.Update(func(d *Data) {
+		d.Establishments = append(d.Establishments, fhrs.Establishment{FHRSID: 5, BusinessName: "New Cafe"})
+	})
+
+	e, err := client.Establishments.GetByID("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.BusinessName != "New Cafe" {
+		t.Errorf("Expected New Cafe but got %s", e.BusinessName)
+	}
+}
+
+func TestNewServerFromFile(t *testing.T) {
+	server, err := NewServerFromFile("testdata/data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := client.Establishments.GetByID("82940")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.BusinessName != "The Pizza Place" {
+		t.Errorf("Expected The Pizza Place but got %s", e.BusinessName)
+	}
+}
+
+func TestServeHTTP_Errors(t *testing.T) {
+	server := NewServer(Data{})
+	defer server.Close()
+
+	cases := map[string]int{
+		"Unknown":                   http.StatusNotFound,
+		"Establishments/1/2/3":      http.StatusNotFound,
+		"Ratings/1":                 http.StatusNotFound,
+		"Authorities/basic/x/1":     http.StatusNotFound,
+		"Establishments?pageSize=x": http.StatusBadRequest,
+	}
+
+	for path, expected := range cases {
+		res, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != expected {
+			t.Errorf("Expected %s to return %d but got %d", path, expected, res.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package fhrs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The area within which establishments are expected to be, covering Great
+// Britain and Northern Ireland with a little room to spare.
+const (
+	minUKLatitude  = 49.8
+	maxUKLatitude  = 60.9
+	minUKLongitude = -8.7
+	maxUKLongitude = 1.8
+)
+
+// ErrNoLocation is returned when a Geocode has no coordinates.
+var ErrNoLocation = errors.New("No location")
+
+// Coordinates returns the parsed latitude and longitude, and false if either
+// is missing or invalid.
+func (g Geocode) Coordinates() (latitude, longitude float64, ok bool) {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(g.Latitude), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	longitude, err = strconv.ParseFloat(strings.TrimSpace(g.Longitude), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return latitude, longitude, true
+}
+
+// HasLocation reports whether the Geocode has valid coordinates.
+func (g Geocode) HasLocation() bool {
+	_, _, ok := g.Coordinates()
+	return ok
+}
+
+// Validate returns ErrNoLocation if the Geocode has no coordinates, or an
+// error if they are outside the UK.
+func (g Geocode) Validate() error {
+	latitude, longitude, ok := g.Coordinates()
+	if !ok {
+		return ErrNoLocation
+	}
+
+	return validateUKLocation(latitude, longitude)
+}
+
+// Near returns SearchParams for establishments within maxDistanceLimit miles
+// of the Geocode.
+func (g Geocode) Near(maxDistanceLimit int) (*SearchParams, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	if maxDistanceLimit <= 0 {
+		return nil, errors.New("Max distance limit must be positive")
+	}
+
+	latitude, longitude, _ := g.Coordinates()
+
+	return &SearchParams{
+		Latitude:         &latitude,
+		Longitude:        &longitude,
+		MaxDistanceLimit: &maxDistanceLimit,
+	}, nil
+}
+
+func validateUKLocation(latitude, longitude float64) error {
+	if !inUK(latitude, longitude) {
+		return fmt.Errorf("Location %v, %v is outside the UK", latitude, longitude)
+	}
+
+	return nil
+}
+
+func inUK(latitude, longitude float64) bool {
+	return latitude >= minUKLatitude && latitude <= maxUKLatitude &&
+		longitude >= minUKLongitude && longitude <= maxUKLongitude
+}
@@ -0,0 +1,76 @@
+package fhrs
+
+import (
+	"testing"
+)
+
+func TestGeocodeCoordinates(t *testing.T) {
+	cases := []struct {
+		geocode   Geocode
+		latitude  float64
+		longitude float64
+		ok        bool
+	}{
+		{
+			geocode:   Geocode{Longitude: "-1.09159100055695", Latitude: "50.7984199523926"},
+			latitude:  50.7984199523926,
+			longitude: -1.09159100055695,
+			ok:        true,
+		},
+		{geocode: Geocode{}, ok: false},
+		{geocode: Geocode{Longitude: "-1.09159100055695"}, ok: false},
+		{geocode: Geocode{Longitude: "west", Latitude: "north"}, ok: false},
+	}
+
+	for _, c := range cases {
+		latitude, longitude, ok := c.geocode.Coordinates()
+		if latitude != c.latitude || longitude != c.longitude || ok != c.ok {
+			t.Errorf("Expected %+v to give %v, %v, %t but got %v, %v, %t",
+				c.geocode, c.latitude, c.longitude, c.ok, latitude, longitude, ok)
+		}
+
+		if c.geocode.HasLocation() != c.ok {
+			t.Errorf("Expected HasLocation for %+v to be %t", c.geocode, c.ok)
+		}
+	}
+}
+
+func TestGeocodeValidate(t *testing.T) {
+	if err := (Geocode{Longitude: "-1.0916", Latitude: "50.7984"}).Validate(); err != nil {
+		t.Errorf("Expected Portsmouth to be valid but got %v", err)
+	}
+
+	if err := (Geocode{Longitude: "-5.9301", Latitude: "54.5973"}).Validate(); err != nil {
+		t.Errorf("Expected Belfast to be valid but got %v", err)
+	}
+
+	if err := (Geocode{}).Validate(); err != ErrNoLocation {
+		t.Errorf("Expected ErrNoLocation but got %v", err)
+	}
+
+	if err := (Geocode{Longitude: "2.3522", Latitude: "48.8566"}).Validate(); err == nil {
+		t.Error("Expected Paris to be outside the UK")
+	}
+}
+
+func TestGeocodeNear(t *testing.T) {
+	g := Geocode{Longitude: "-1.0916", Latitude: "50.7984"}
+
+	params, err := g.Near(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *params.Latitude != 50.7984 || *params.Longitude != -1.0916 || *params.MaxDistanceLimit != 5 {
+		t.Errorf("Expected params near Portsmouth but got %v, %v, %v",
+			*params.Latitude, *params.Longitude, *params.MaxDistanceLimit)
+	}
+
+	if _, err := g.Near(0); err == nil {
+		t.Error("Expected an error for a zero distance")
+	}
+
+	if _, err := (Geocode{}).Near(5); err != ErrNoLocation {
+		t.Errorf("Expected ErrNoLocation but got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package fhrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// These types name the values the API accepts for the keys in SearchParams.
+// The constants cover the values known when this package was written. As the
+// types are strings, a value the API adds later can still be used by
+// conversion, such as SortOption("new_option").
+
+// SchemeType is a food hygiene rating scheme, as used by
+// SearchParams.SchemeTypeKey.
+type SchemeType string
+
+const (
+	SchemeTypeFHRS SchemeType = "FHRS" // Food Hygiene Rating Scheme, used in England, Wales and Northern Ireland.
+	SchemeTypeFHIS SchemeType = "FHIS" // Food Hygiene Information Scheme, used in Scotland.
+)
+
+var schemeTypes = []SchemeType{SchemeTypeFHRS, SchemeTypeFHIS}
+
+// ParseSchemeType returns the known SchemeType matching s, ignoring case.
+func ParseSchemeType(s string) (SchemeType, error) {
+	for _, v := range schemeTypes {
+		if strings.EqualFold(s, string(v)) {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("Unknown scheme type %q", s)
+}
+
+// Known reports whether s is one of the SchemeType constants.
+func (s SchemeType) Known() bool {
+	_, err := ParseSchemeType(string(s))
+	return err == nil
+}
+
+func (s SchemeType) String() string {
+	return string(s)
+}
+
+// SortOption is an ordering for search results, as used by
+// SearchParams.SortOptionKey. The constants are named for the order they give,
+// as the API's keys are misleading: "desc_rating" puts the lowest rating first.
+type SortOption string
+
+const (
+	SortOptionRelevance     SortOption = "Relevance"
+	SortOptionRatingHighest SortOption = "rating"      // Highest rating first.
+	SortOptionRatingLowest  SortOption = "desc_rating" // Lowest rating first.
+	SortOptionName          SortOption = "alpha"       // A to Z.
+	SortOptionNameDesc      SortOption = "desc_alpha"  // Z to A.
+	SortOptionDistance      SortOption = "Distance"    // Nearest first, when searching by location.
+)
+
+var sortOptions = []SortOption{
+	SortOptionRelevance,
+	SortOptionRatingHighest,
+	SortOptionRatingLowest,
+	SortOptionName,
+	SortOptionNameDesc,
+	SortOptionDistance,
+}
+
+// ParseSortOption returns the known SortOption matching s, ignoring case.
+func ParseSortOption(s string) (SortOption, error) {
+	for _, v := range sortOptions {
+		if strings.EqualFold(s, string(v)) {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("Unknown sort option %q", s)
+}
+
+// Known reports whether s is one of the SortOption constants.
+func (s SortOption) Known() bool {
+	_, err := ParseSortOption(string(s))
+	return err == nil
+}
+
+func (s SortOption) String() string {
+	return string(s)
+}
+
+// RatingOperator is a comparison for SearchParams.RatingKey, as used by
+// SearchParams.RatingOperatorKey.
+type RatingOperator string
+
+const (
+	RatingOperatorEqual              RatingOperator = "Equal"
+	RatingOperatorGreaterThanOrEqual RatingOperator = "GreaterThanOrEqual"
+	RatingOperatorLessThanOrEqual    RatingOperator = "LessThanOrEqual"
+)
+
+var ratingOperators = []RatingOperator{
+	RatingOperatorEqual,
+	RatingOperatorGreaterThanOrEqual,
+	RatingOperatorLessThanOrEqual,
+}
+
+// ParseRatingOperator returns the known RatingOperator matching s, ignoring
+// case.
+func ParseRatingOperator(s string) (RatingOperator, error) {
+	for _, v := range ratingOperators {
+		if strings.EqualFold(s, string(v)) {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("Unknown rating operator %q", s)
+}
+
+// Known reports whether o is one of the RatingOperator constants.
+func (o RatingOperator) Known() bool {
+	_, err := ParseRatingOperator(string(o))
+	return err == nil
+}
+
+func (o RatingOperator) String() string {
+	return string(o)
+}
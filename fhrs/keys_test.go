@@ -0,0 +1,73 @@
+package fhrs
+
+import (
+	"testing"
+)
+
+func TestParseSchemeType(t *testing.T) {
+	for _, s := range []string{"FHRS", "fhrs"} {
+		if v, err := ParseSchemeType(s); err != nil || v != SchemeTypeFHRS {
+			t.Errorf("Expected %q to parse as FHRS but got %v, %v", s, v, err)
+		}
+	}
+
+	if _, err := ParseSchemeType("FHXS"); err == nil {
+		t.Error("Expected an error for an unknown scheme type")
+	}
+
+	if SchemeType("").Known() || !SchemeTypeFHIS.Known() {
+		t.Error("Expected only the constants to be known")
+	}
+
+	if SchemeTypeFHIS.String() != "FHIS" {
+		t.Errorf("Expected FHIS but got %s", SchemeTypeFHIS)
+	}
+}
+
+func TestParseSortOption(t *testing.T) {
+	cases := map[string]SortOption{
+		"Relevance":   SortOptionRelevance,
+		"rating":      SortOptionRatingHighest,
+		"desc_rating": SortOptionRatingLowest,
+		"alpha":       SortOptionName,
+		"DESC_ALPHA":  SortOptionNameDesc,
+		"distance":    SortOptionDistance,
+	}
+
+	for s, want := range cases {
+		if v, err := ParseSortOption(s); err != nil || v != want {
+			t.Errorf("Expected %q to parse as %s but got %v, %v", s, want, v, err)
+		}
+	}
+
+	if _, err := ParseSortOption("ratting"); err == nil {
+		t.Error("Expected an error for an unknown sort option")
+	}
+
+	// Values added by the API later can still be used.
+	if SortOption("newest").Known() {
+		t.Error("Expected an unlisted sort option not to be known")
+	}
+}
+
+func TestParseRatingOperator(t *testing.T) {
+	cases := map[string]RatingOperator{
+		"Equal":              RatingOperatorEqual,
+		"greaterthanorequal": RatingOperatorGreaterThanOrEqual,
+		"LessThanOrEqual":    RatingOperatorLessThanOrEqual,
+	}
+
+	for s, want := range cases {
+		if v, err := ParseRatingOperator(s); err != nil || v != want {
+			t.Errorf("Expected %q to parse as %s but got %v, %v", s, want, v, err)
+		}
+	}
+
+	if _, err := ParseRatingOperator("GreaterThan"); err == nil {
+		t.Error("Expected an error for an unknown rating operator")
+	}
+
+	if RatingOperatorEqual.String() != "Equal" {
+		t.Errorf("Expected Equal but got %s", RatingOperatorEqual)
+	}
+}
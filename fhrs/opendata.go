@@ -0,0 +1,275 @@
+package fhrs
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// openDataRoot is the root element of an open data file.
+const openDataRoot = "FHRSEstablishment"
+
+// xmlHeader is the Header element of an open data file.
+type xmlHeader struct {
+	ExtractDate string `xml:"ExtractDate"`
+	ItemCount   int    `xml:"ItemCount"`
+	ReturnCode  string `xml:"ReturnCode"`
+}
+
+// xmlEstablishment is an EstablishmentDetail element of an open data file.
+// Values which may be empty or marked xsi:nil are read as strings and parsed
+// afterwards.
+type xmlEstablishment struct {
+	FHRSID                     int    `xml:"FHRSID"`
+	LocalAuthorityBusinessID   string `xml:"LocalAuthorityBusinessID"`
+	BusinessName               string `xml:"BusinessName"`
+	BusinessType               string `xml:"BusinessType"`
+	BusinessTypeID             string `xml:"BusinessTypeID"`
+	AddressLine1               string `xml:"AddressLine1"`
+	AddressLine2               string `xml:"AddressLine2"`
+	AddressLine3               string `xml:"AddressLine3"`
+	AddressLine4               string `xml:"AddressLine4"`
+	PostCode                   string `xml:"PostCode"`
+	Phone                      string `xml:"Phone"`
+	RatingValue                string `xml:"RatingValue"`
+	RatingKey                  string `xml:"RatingKey"`
+	RatingDate                 string `xml:"RatingDate"`
+	LocalAuthorityCode         string `xml:"LocalAuthorityCode"`
+	LocalAuthorityName         string `xml:"LocalAuthorityName"`
+	LocalAuthorityWebSite      string `xml:"LocalAuthorityWebSite"`
+	LocalAuthorityEmailAddress string `xml:"LocalAuthorityEmailAddress"`
+	Scores                     struct {
+		Hygiene                string `xml:"Hygiene"`
+		Structural             string `xml:"Structural"`
+		ConfidenceInManagement string `xml:"ConfidenceInManagement"`
+	} `xml:"Scores"`
+	SchemeType       string `xml:"SchemeType"`
+	NewRatingPending string `xml:"NewRatingPending"`
+	Geocode          struct {
+		Longitude string `xml:"Longitude"`
+		Latitude  string `xml:"Latitude"`
+	} `xml:"Geocode"`
+	RightToReply string `xml:"RightToReply"`
+}
+
+// XMLDecoder reads establishments from one of the FSA's open data files, which
+// hold every establishment of a local authority and are published at
+// Authority.FileName, or Authority.FileNameWelsh for Welsh.
+//
+//	dec := fhrs.NewXMLDecoder(r)
+//	for dec.Next() {
+//		est := dec.Establishment()
+//		// Do stuff with est
+//	}
+//	if err := dec.Err(); err != nil {
+//		// Handle err
+//	}
+//
+// The file is decoded as it is read, so large files need not fit in memory.
+// Welsh files are read in the same way. Their text is in Welsh and their
+// rating keys end in cy-GB rather than en-GB, which Establishment.Rating
+// ignores, so ratings compare equally across languages.
+type XMLDecoder struct {
+	dec     *xml.Decoder
+	meta    Meta
+	current *Establishment
+	started bool
+	err     error
+}
+
+// NewXMLDecoder returns a decoder reading an open data file from r.
+func NewXMLDecoder(r io.Reader) *XMLDecoder {
+	return &XMLDecoder{dec: xml.NewDecoder(r)}
+}
+
+// DecodeXML reads every establishment in an open data file. The Meta holds the
+// header of the file.
+func DecodeXML(r io.Reader) (*Establishments, error) {
+	dec := NewXMLDecoder(r)
+	establishments := &Establishments{Establishments: []Establishment{}}
+
+	for dec.Next() {
+		establishments.Establishments = append(establishments.Establishments, *dec.Establishment())
+	}
+
+	if err := dec.Err(); err != nil {
+		return nil, err
+	}
+
+	establishments.Meta = dec.Meta()
+	return establishments, nil
+}
+
+// Next advances to the next establishment. It returns false at the end of the
+// file or if an error occurred.
+func (d *XMLDecoder) Next() bool {
+	d.current = nil
+	if d.err != nil {
+		return false
+	}
+
+	for {
+		tok, err := d.dec.Token()
+		if err == io.EOF {
+			if !d.started {
+				d.err = errors.New("Not an FHRS open data file: no " + openDataRoot + " element")
+			}
+
+			return false
+		}
+
+		if err != nil {
+			d.err = err
+			return false
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if !d.started {
+			if start.Name.Local != openDataRoot {
+				d.err = fmt.Errorf("Not an FHRS open data file: root element is %s", start.Name.Local)
+				return false
+			}
+
+			d.started = true
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Header":
+			if err := d.decodeHeader(start); err != nil {
+				d.err = err
+				return false
+			}
+		case "EstablishmentDetail":
+			var x xmlEstablishment
+			if err := d.dec.DecodeElement(&x, &start); err != nil {
+				d.err = err
+				return false
+			}
+
+			e, err := x.establishment()
+			if err != nil {
+				d.err = err
+				return false
+			}
+
+			d.current = e
+			return true
+		}
+	}
+}
+
+// Establishment returns the current establishment.
+func (d *XMLDecoder) Establishment() *Establishment {
+	return d.current
+}
+
+// Meta returns the header of the file, once it has been read by Next.
+func (d *XMLDecoder) Meta() Meta {
+	return d.meta
+}
+
+// Err returns the error which stopped decoding, if any.
+func (d *XMLDecoder) Err() error {
+	return d.err
+}
+
+func (d *XMLDecoder) decodeHeader(start xml.StartElement) error {
+	var h xmlHeader
+	if err := d.dec.DecodeElement(&h, &start); err != nil {
+		return err
+	}
+
+	extractDate, err := ParseTimestamp(h.ExtractDate)
+	if err != nil {
+		return fmt.Errorf("Invalid ExtractDate %q: %v", h.ExtractDate, err)
+	}
+
+	d.meta = Meta{
+		ExtractDate: extractDate,
+		ItemCount:   h.ItemCount,
+		Returncode:  h.ReturnCode,
+	}
+
+	return nil
+}
+
+// establishment converts x to an Establishment.
+func (x *xmlEstablishment) establishment() (*Establishment, error) {
+	invalid := func(field, value string) error {
+		return fmt.Errorf("Invalid %s %q for FHRSID %d", field, value, x.FHRSID)
+	}
+
+	e := &Establishment{
+		FHRSID:                     x.FHRSID,
+		LocalAuthorityBusinessID:   x.LocalAuthorityBusinessID,
+		BusinessName:               x.BusinessName,
+		BusinessType:               x.BusinessType,
+		AddressLine1:               x.AddressLine1,
+		AddressLine2:               x.AddressLine2,
+		AddressLine3:               x.AddressLine3,
+		AddressLine4:               x.AddressLine4,
+		PostCode:                   x.PostCode,
+		Phone:                      x.Phone,
+		RatingValue:                x.RatingValue,
+		RatingKey:                  x.RatingKey,
+		LocalAuthorityCode:         x.LocalAuthorityCode,
+		LocalAuthorityName:         x.LocalAuthorityName,
+		LocalAuthorityWebSite:      x.LocalAuthorityWebSite,
+		LocalAuthorityEmailAddress: x.LocalAuthorityEmailAddress,
+		SchemeType:                 x.SchemeType,
+		Geocode:                    Geocode{Longitude: x.Geocode.Longitude, Latitude: x.Geocode.Latitude},
+		RightToReply:               x.RightToReply,
+	}
+
+	var err error
+
+	if s := strings.TrimSpace(x.BusinessTypeID); s != "" {
+		if e.BusinessTypeID, err = strconv.Atoi(s); err != nil {
+			return nil, invalid("BusinessTypeID", s)
+		}
+	}
+
+	if e.RatingDate, err = ParseTimestamp(x.RatingDate); err != nil {
+		return nil, invalid("RatingDate", x.RatingDate)
+	}
+
+	scores := []struct {
+		name  string
+		value string
+		score **int
+	}{
+		{"Hygiene", x.Scores.Hygiene, &e.Scores.Hygiene},
+		{"Structural", x.Scores.Structural, &e.Scores.Structural},
+		{"ConfidenceInManagement", x.Scores.ConfidenceInManagement, &e.Scores.ConfidenceInManagement},
+	}
+
+	for _, s := range scores {
+		v := strings.TrimSpace(s.value)
+		if v == "" {
+			continue
+		}
+
+		score, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, invalid(s.name, v)
+		}
+
+		*s.score = &score
+	}
+
+	if s := strings.TrimSpace(x.NewRatingPending); s != "" {
+		if e.NewRatingPending, err = strconv.ParseBool(s); err != nil {
+			return nil, invalid("NewRatingPending", s)
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,231 @@
+package fhrs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const openDataBody = `<?xml version="1.0" encoding="utf-8"?>
+<FHRSEstablishment xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <Header>
+    <ExtractDate>2020-01-31</ExtractDate>
+    <ItemCount>2</ItemCount>
+    <ReturnCode>Success</ReturnCode>
+  </Header>
+  <EstablishmentCollection>
+    <EstablishmentDetail>
+      <FHRSID>82940</FHRSID>
+      <LocalAuthorityBusinessID>PI/000069980</LocalAuthorityBusinessID>
+      <BusinessName>The Pizza Place</BusinessName>
+      <BusinessType>Restaurant/Cafe/Canteen</BusinessType>
+      <BusinessTypeID>1</BusinessTypeID>
+      <AddressLine1>1 High Street</AddressLine1>
+      <AddressLine2>Portsmouth</AddressLine2>
+      <PostCode>PO1 1AA</PostCode>
+      <RatingValue>5</RatingValue>
+      <RatingKey>fhrs_5_en-GB</RatingKey>
+      <RatingDate>2019-05-01</RatingDate>
+      <LocalAuthorityCode>876</LocalAuthorityCode>
+      <LocalAuthorityName>Portsmouth</LocalAuthorityName>
+      <LocalAuthorityWebSite>http://www.portsmouth.gov.uk</LocalAuthorityWebSite>
+      <LocalAuthorityEmailAddress>food@portsmouthcc.gov.uk</LocalAuthorityEmailAddress>
+      <Scores>
+        <Hygiene>0</Hygiene>
+        <Structural>5</Structural>
+        <ConfidenceInManagement>0</ConfidenceInManagement>
+      </Scores>
+      <SchemeType>FHRS</SchemeType>
+      <NewRatingPending>True</NewRatingPending>
+      <Geocode>
+        <Longitude>-1.09160000000000</Longitude>
+        <Latitude>50.79840000000000</Latitude>
+      </Geocode>
+      <RightToReply>We have since retrained all staff.</RightToReply>
+    </EstablishmentDetail>
+    <EstablishmentDetail>
+      <FHRSID>82941</FHRSID>
+      <LocalAuthorityBusinessID>PI/000069981</LocalAuthorityBusinessID>
+      <BusinessName>Corner Shop</BusinessName>
+      <BusinessType>Retailers - other</BusinessType>
+      <BusinessTypeID>4613</BusinessTypeID>
+      <PostCode>PO1 2BB</PostCode>
+      <RatingValue>AwaitingInspection</RatingValue>
+      <RatingKey>fhrs_awaitinginspection_en-GB</RatingKey>
+      <RatingDate xsi:nil="true" />
+      <LocalAuthorityCode>876</LocalAuthorityCode>
+      <LocalAuthorityName>Portsmouth</LocalAuthorityName>
+      <Scores />
+      <SchemeType>FHRS</SchemeType>
+      <NewRatingPending>False</NewRatingPending>
+      <Geocode />
+    </EstablishmentDetail>
+  </EstablishmentCollection>
+</FHRSEstablishment>`
+
+const openDataWelshBody = `<?xml version="1.0" encoding="utf-8"?>
+<FHRSEstablishment xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <Header>
+    <ExtractDate>2020-01-31</ExtractDate>
+    <ItemCount>1</ItemCount>
+    <ReturnCode>Llwyddiant</ReturnCode>
+  </Header>
+  <EstablishmentCollection>
+    <EstablishmentDetail>
+      <FHRSID>1000</FHRSID>
+      <BusinessName>Caffi'r Castell</BusinessName>
+      <BusinessType>Bwyty/Caffi/Ffreutur</BusinessType>
+      <BusinessTypeID>1</BusinessTypeID>
+      <AddressLine1>Stryd Fawr</AddressLine1>
+      <AddressLine2>Caernarfon</AddressLine2>
+      <PostCode>LL55 1AA</PostCode>
+      <RatingValue>4</RatingValue>
+      <RatingKey>fhrs_4_cy-GB</RatingKey>
+      <RatingDate>2019-11-20</RatingDate>
+      <LocalAuthorityCode>557</LocalAuthorityCode>
+      <LocalAuthorityName>Gwynedd</LocalAuthorityName>
+      <SchemeType>FHRS</SchemeType>
+      <NewRatingPending>False</NewRatingPending>
+    </EstablishmentDetail>
+  </EstablishmentCollection>
+</FHRSEstablishment>`
+
+func TestDecodeXML(t *testing.T) {
+	actual, err := DecodeXML(strings.NewReader(openDataBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hygiene, structural, confidence := 0, 5, 0
+
+	expected := &Establishments{
+		Establishments: []Establishment{
+			{
+				FHRSID:                     82940,
+				LocalAuthorityBusinessID:   "PI/000069980",
+				BusinessName:               "The Pizza Place",
+				BusinessType:               "Restaurant/Cafe/Canteen",
+				BusinessTypeID:             1,
+				AddressLine1:               "1 High Street",
+				AddressLine2:               "Portsmouth",
+				PostCode:                   "PO1 1AA",
+				RatingValue:                "5",
+				RatingKey:                  "fhrs_5_en-GB",
+				RatingDate:                 Timestamp(time.Date(2019, 5, 1, 0, 0, 0, 0, ukLocation)),
+				LocalAuthorityCode:         "876",
+				LocalAuthorityName:         "Portsmouth",
+				LocalAuthorityWebSite:      "http://www.portsmouth.gov.uk",
+				LocalAuthorityEmailAddress: "food@portsmouthcc.gov.uk",
+				Scores: Scores{
+					Hygiene:                &hygiene,
+					Structural:             &structural,
+					ConfidenceInManagement: &confidence,
+				},
+				SchemeType:       "FHRS",
+				NewRatingPending: true,
+				Geocode: Geocode{
+					Longitude: "-1.09160000000000",
+					Latitude:  "50.79840000000000",
+				},
+				RightToReply: "We have since retrained all staff.",
+			},
+			{
+				FHRSID:                   82941,
+				LocalAuthorityBusinessID: "PI/000069981",
+				BusinessName:             "Corner Shop",
+				BusinessType:             "Retailers - other",
+				BusinessTypeID:           4613,
+				PostCode:                 "PO1 2BB",
+				RatingValue:              "AwaitingInspection",
+				RatingKey:                "fhrs_awaitinginspection_en-GB",
+				LocalAuthorityCode:       "876",
+				LocalAuthorityName:       "Portsmouth",
+				SchemeType:               "FHRS",
+			},
+		},
+		Meta: Meta{
+			ExtractDate: Timestamp(time.Date(2020, 1, 31, 0, 0, 0, 0, ukLocation)),
+			ItemCount:   2,
+			Returncode:  "Success",
+		},
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+
+	if !actual.Establishments[0].Geocode.HasLocation() {
+		t.Error("Expected the first establishment to have a location")
+	}
+
+	if r := actual.Establishments[1].Rating(); r.Status != RatingStatusAwaitingInspection {
+		t.Errorf("Expected the second establishment to be awaiting inspection but got %v", r.Status)
+	}
+}
+
+func TestDecodeXML_Welsh(t *testing.T) {
+	actual, err := DecodeXML(strings.NewReader(openDataWelshBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual.Establishments) != 1 {
+		t.Fatalf("Expected 1 establishment but got %d", len(actual.Establishments))
+	}
+
+	e := actual.Establishments[0]
+	if e.BusinessName != "Caffi'r Castell" || e.BusinessType != "Bwyty/Caffi/Ffreutur" {
+		t.Errorf("Unexpected establishment %+v", e)
+	}
+
+	english := ParseRatingValue("4", "fhrs_4_en-GB")
+	if e.Rating().Compare(english) != 0 || e.Rating().Key != english.Key {
+		t.Errorf("Expected the Welsh rating %+v to equal %+v", e.Rating(), english)
+	}
+}
+
+func TestXMLDecoder_Stream(t *testing.T) {
+	dec := NewXMLDecoder(strings.NewReader(openDataBody))
+
+	var ids []int
+	for dec.Next() {
+		ids = append(ids, dec.Establishment().FHRSID)
+
+		if dec.Meta().ItemCount != 2 {
+			t.Errorf("Expected the header to be read before the first establishment")
+		}
+	}
+
+	if err := dec.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual([]int{82940, 82941}, ids) {
+		t.Errorf("Unexpected establishments %v", ids)
+	}
+
+	if dec.Next() || dec.Establishment() != nil {
+		t.Error("Expected no more establishments")
+	}
+}
+
+func TestDecodeXML_Errors(t *testing.T) {
+	cases := map[string]string{
+		"Empty":     ``,
+		"WrongRoot": `<Establishments></Establishments>`,
+		"Truncated": openDataBody[:len(openDataBody)/2],
+		"BadScore": `<FHRSEstablishment><EstablishmentCollection><EstablishmentDetail>
+			<FHRSID>1</FHRSID><Scores><Hygiene>ten</Hygiene></Scores>
+			</EstablishmentDetail></EstablishmentCollection></FHRSEstablishment>`,
+		"BadDate": `<FHRSEstablishment><EstablishmentCollection><EstablishmentDetail>
+			<FHRSID>1</FHRSID><RatingDate>yesterday</RatingDate>
+			</EstablishmentDetail></EstablishmentCollection></FHRSEstablishment>`,
+	}
+
+	for name, body := range cases {
+		if _, err := DecodeXML(strings.NewReader(body)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
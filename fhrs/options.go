@@ -0,0 +1,87 @@
+package fhrs
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a Client. Options are applied in the order given to
+// NewClient.
+type Option func(*Client) error
+
+// WithHTTPClient sets the http.Client used to make requests. This allows
+// custom transports, proxies and instrumentation to be used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("HTTP client must not be nil")
+		}
+
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the URL requests are made against, for example to point the
+// Client at a local fake of the API.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return err
+		}
+
+		// Paths are resolved relative to the base URL so it must be a
+		// "directory" for the last segment to be kept.
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request. It applies to the
+// http.Client given by WithHTTPClient without modifying the original.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("Timeout must be positive")
+		}
+
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithLanguage sets the response language. See Client.SetLanguage.
+func WithLanguage(l APILanguage) Option {
+	return func(c *Client) error {
+		return c.SetLanguage(l)
+	}
+}
+
+// WithAPIVersion sets the value of the x-api-version header. The Client is
+// written against version 2 of the API.
+func WithAPIVersion(version int) Option {
+	return func(c *Client) error {
+		if version < 1 {
+			return errors.New("API version must be at least 1")
+		}
+
+		c.version = version
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	c, err := NewClient(
+		WithHTTPClient(httpClient),
+		WithBaseURL("http://localhost:8080/fhrs"),
+		WithUserAgent("go-fhrs-test"),
+		WithTimeout(5*time.Second),
+		WithLanguage(LanguageCymraeg),
+		WithAPIVersion(1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.baseURL.String() != "http://localhost:8080/fhrs/" {
+		t.Errorf("Expected base URL to be http://localhost:8080/fhrs/ but got %s", c.baseURL)
+	}
+
+	if c.userAgent != "go-fhrs-test" {
+		t.Errorf("Expected user agent to be go-fhrs-test but got %s", c.userAgent)
+	}
+
+	if c.language != LanguageCymraeg {
+		t.Errorf("Expected language to be %s but got %s", LanguageCymraeg, c.language)
+	}
+
+	if c.version != 1 {
+		t.Errorf("Expected version to be 1 but got %d", c.version)
+	}
+
+	if c.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout to be 5s but got %s", c.httpClient.Timeout)
+	}
+
+	if httpClient.Timeout != time.Minute {
+		t.Error("Expected the given http.Client not to be modified")
+	}
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	cases := map[string]Option{
+		"nil HTTP client":  WithHTTPClient(nil),
+		"bad base URL":     WithBaseURL("://"),
+		"negative timeout": WithTimeout(-time.Second),
+		"bad language":     WithLanguage(9),
+		"bad API version":  WithAPIVersion(0),
+	}
+
+	for name, opt := range cases {
+		if _, err := NewClient(opt); err == nil {
+			t.Errorf("Expected an error for %s but got nil", name)
+		}
+	}
+}
+
+func TestNewClient_Headers(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		if ua := r.Header.Get("User-Agent"); ua != "go-fhrs-test" {
+			t.Errorf("Expected User-Agent to be go-fhrs-test but got %s", ua)
+		}
+
+		if ah := r.Header.Get("x-api-version"); ah != "3" {
+			t.Errorf("Expected x-api-version to be 3 but got %s", ah)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [] }`)
+	})
+
+	for _, opt := range []Option{WithUserAgent("go-fhrs-test"), WithAPIVersion(3)} {
+		if err := opt(client); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, err := client.Ratings.Get(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,136 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+)
+
+// EstablishmentIterator walks every establishment matching a search, fetching
+// pages lazily as it goes.
+//
+//	it := client.Establishments.SearchAll(ctx, params)
+//	for it.Next() {
+//		est := it.Establishment()
+//		// Do stuff with est
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle err
+//	}
+//
+// The total number of pages is re-read from each page, so results added or
+// removed during the walk are tolerated. Establishments which move onto a
+// later page are only returned once.
+type EstablishmentIterator struct {
+	ctx        context.Context
+	service    *EstablishmentsService
+	params     SearchParams
+	pageNumber int
+	page       []Establishment
+	index      int
+	meta       Meta
+	seen       map[int]bool
+	current    *Establishment
+	err        error
+	done       bool
+}
+
+// SearchAll returns an iterator over every establishment matching params,
+// starting from params.PageNumber if it is set. params is copied so may be
+// reused by the caller.
+func (s *EstablishmentsService) SearchAll(ctx context.Context, params *SearchParams) *EstablishmentIterator {
+	it := &EstablishmentIterator{
+		ctx:     ctx,
+		service: s,
+		seen:    map[int]bool{},
+	}
+
+	if params != nil {
+		it.params = *params
+	}
+
+	if it.params.PageNumber != nil {
+		it.pageNumber = *it.params.PageNumber - 1
+	}
+
+	return it
+}
+
+// Next advances to the next establishment, fetching the next page if needed. It
+// returns false when there are no more establishments or an error occurred.
+func (it *EstablishmentIterator) Next() bool {
+	for {
+		if it.err != nil || it.done {
+			return false
+		}
+
+		if it.index < len(it.page) {
+			est := &it.page[it.index]
+			it.index++
+
+			if it.seen[est.FHRSID] {
+				continue
+			}
+
+			it.seen[est.FHRSID] = true
+			it.current = est
+			return true
+		}
+
+		if it.fetched() && it.meta.TotalPages > 0 && it.pageNumber >= it.meta.TotalPages {
+			it.done = true
+			return false
+		}
+
+		it.fetch()
+	}
+}
+
+// Establishment returns the current establishment.
+func (it *EstablishmentIterator) Establishment() *Establishment {
+	return it.current
+}
+
+// Meta returns the metadata of the most recently fetched page.
+func (it *EstablishmentIterator) Meta() Meta {
+	return it.meta
+}
+
+// Err returns the error which stopped the iterator, if any.
+func (it *EstablishmentIterator) Err() error {
+	return it.err
+}
+
+// fetched reports whether any page has been fetched yet.
+func (it *EstablishmentIterator) fetched() bool {
+	return it.page != nil
+}
+
+func (it *EstablishmentIterator) fetch() {
+	pageNumber := it.pageNumber + 1
+
+	params := it.params
+	params.PageNumber = &pageNumber
+
+	// An empty page means we've walked off the end, even if the total has
+	// shrunk since the last page was fetched.
+	res, err := it.service.SearchContext(it.ctx, &params)
+	if errors.Is(err, ErrEmptyResponse) {
+		it.done = true
+		return
+	}
+
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	if len(res.Establishments) == 0 {
+		it.done = true
+		return
+	}
+
+	it.pageNumber = pageNumber
+	it.meta = res.Meta
+	it.page = res.Establishments
+	it.index = 0
+}
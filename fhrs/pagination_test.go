@@ -0,0 +1,162 @@
+package fhrs
+
+import (
+	"context"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pageBody builds a search response containing establishments with the given
+// FHRSIDs.
+func pageBody(pageNumber, totalPages int, ids ...int) string {
+	establishments := make([]string, len(ids))
+	for i, id := range ids {
+		establishments[i] = fmt.Sprintf(`{ "FHRSID": %d }`, id)
+	}
+
+	return fmt.Sprintf(
+		`{ "establishments": [%s], "meta": { "pageNumber": %d, "totalPages": %d } }`,
+		strings.Join(establishments, ","), pageNumber, totalPages,
+	)
+}
+
+func TestSearchAll(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	// The total grows from 2 to 3 pages mid-walk, and establishment 3 moves
+	// from page 2 to page 3.
+	pages := map[string]string{
+		"1": pageBody(1, 2, 1, 2),
+		"2": pageBody(2, 3, 3, 4),
+		"3": pageBody(3, 3, 3, 5),
+	}
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		q := r.URL.Query()
+		if q.Get("name") != "pizza" {
+			t.Errorf("Expected param 'name' to equal pizza but got %s", q.Get("name"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, pages[q.Get("pageNumber")])
+	})
+
+	params := &SearchParams{Name: "pizza"}
+	it := client.Establishments.SearchAll(context.Background(), params)
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Establishment().FHRSID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("Expected establishments [1 2 3 4 5] but got %v", ids)
+	}
+
+	if it.Meta().PageNumber != 3 {
+		t.Errorf("Expected last page to be 3 but got %d", it.Meta().PageNumber)
+	}
+
+	if params.PageNumber != nil {
+		t.Error("Expected params not to be modified")
+	}
+}
+
+func TestSearchAll_ShrinkingTotal(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	calls := 0
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls++
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if pageNumber == 1 {
+			io.WriteString(w, pageBody(1, 5, 1, 2))
+			return
+		}
+
+		io.WriteString(w, pageBody(pageNumber, 5))
+	})
+
+	it := client.Establishments.SearchAll(context.Background(), nil)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 establishments but got %d", count)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected to stop at the first empty page after 2 calls but made %d", calls)
+	}
+}
+
+func TestSearchAll_Error(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	router.GET("/Establishments", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageNumber") == "3" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{ "Message": "The request is invalid" }`)
+			return
+		}
+
+		io.WriteString(w, pageBody(2, 4, 10))
+	})
+
+	pageNumber := 2
+	it := client.Establishments.SearchAll(context.Background(), &SearchParams{PageNumber: &pageNumber})
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("Expected 1 establishment before the error but got %d", count)
+	}
+
+	if _, ok := it.Err().(APIError); !ok {
+		t.Errorf("Expected err to be APIError but type is %T", it.Err())
+	}
+
+	if it.Next() {
+		t.Error("Expected Next to keep returning false after an error")
+	}
+}
@@ -0,0 +1,139 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request a Client makes.
+//
+// Tokens are added at a steady rate up to a maximum of burst, and each request
+// takes one, waiting for it if the bucket is empty. It is safe for concurrent
+// use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second.
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond on average,
+// with bursts of up to burst requests. The bucket starts full.
+func NewRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, error) {
+	if requestsPerSecond <= 0 || math.IsInf(requestsPerSecond, 0) || math.IsNaN(requestsPerSecond) {
+		return nil, errors.New("Requests per second must be positive")
+	}
+
+	if burst < 1 {
+		return nil, errors.New("Burst must be at least 1")
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}, nil
+}
+
+// WithRateLimit limits the Client to requestsPerSecond on average, with bursts
+// of up to burst requests. The limit is shared by all services and goroutines
+// using the Client.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		limiter, err := NewRateLimiter(requestsPerSecond, burst)
+		if err != nil {
+			return err
+		}
+
+		c.rateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimiter sets the RateLimiter used by the Client. Sharing one between
+// Clients makes them share a single limit.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.rateLimiter = limiter
+		return nil
+	}
+}
+
+// RateLimiter returns the Client's RateLimiter, or nil if requests are not
+// limited.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
+
+// Wait blocks until a request may be made or ctx is done. When ctx is done
+// the reserved token is returned to the bucket.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill()
+	l.tokens--
+	delay := l.delayLocked()
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Delay returns how long a request made now would have to wait. This
+// includes the requests already waiting, so it grows as callers queue up.
+func (l *RateLimiter) Delay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens--
+	defer func() { l.tokens++ }()
+
+	return l.delayLocked()
+}
+
+// Tokens returns the number of requests which can currently be made without
+// waiting. It is negative when callers are waiting.
+func (l *RateLimiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	return l.tokens
+}
+
+// refill adds the tokens accrued since the last call. l.mu must be held.
+func (l *RateLimiter) refill() {
+	now := l.now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+	}
+
+	l.last = now
+}
+
+// delayLocked returns how long until the bucket stops being in debt. l.mu must
+// be held.
+func (l *RateLimiter) delayLocked() time.Duration {
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
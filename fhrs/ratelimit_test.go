@@ -0,0 +1,117 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiter_Invalid(t *testing.T) {
+	if _, err := NewRateLimiter(0, 1); err == nil {
+		t.Error("Expected an error for a zero rate")
+	}
+
+	if _, err := NewRateLimiter(1, 0); err == nil {
+		t.Error("Expected an error for a zero burst")
+	}
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	l, err := NewRateLimiter(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2020, 2, 3, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	ctx := context.Background()
+
+	// The bucket starts full, so the burst goes straight through.
+	for i := 0; i < 2; i++ {
+		if d := l.Delay(); d != 0 {
+			t.Errorf("Expected no delay within the burst but got %s", d)
+		}
+
+		if err := l.Wait(ctx); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if d := l.Delay(); d != 500*time.Millisecond {
+		t.Errorf("Expected a delay of 500ms once the burst is used but got %s", d)
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	if d := l.Delay(); d != 250*time.Millisecond {
+		t.Errorf("Expected a delay of 250ms after refilling for 250ms but got %s", d)
+	}
+
+	now = now.Add(time.Hour)
+	if tokens := l.Tokens(); tokens != 2 {
+		t.Errorf("Expected the bucket to refill to the burst of 2 but got %v", tokens)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l, err := NewRateLimiter(0.001, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected err to be context.DeadlineExceeded but got %v", err)
+	}
+
+	// The cancelled caller should not hold on to its token.
+	if tokens := l.Tokens(); tokens < 0 {
+		t.Errorf("Expected the cancelled token to be returned but have %v tokens", tokens)
+	}
+}
+
+func TestRateLimiter_Client(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	if err := WithRateLimit(20, 1)(client); err != nil {
+		t.Error(err)
+	}
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [] }`)
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ratings.Get(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// One request from the burst, then two at 50ms intervals.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected requests to be limited to take at least 100ms but took %s", elapsed)
+	}
+
+	if client.RateLimiter() == nil {
+		t.Error("Expected the client to expose its RateLimiter")
+	}
+}
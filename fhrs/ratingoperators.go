@@ -0,0 +1,47 @@
+package fhrs
+
+import (
+	"context"
+)
+
+// RatingOperatorsService encapsulates the RatingOperators methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#RatingOperators
+type RatingOperatorsService service
+
+// RatingOperators is the list of comparisons available when searching by
+// rating.
+//
+// Note that the API names the list "ratingOperator", in the singular.
+type RatingOperators struct {
+	RatingOperators []RatingOperatorDetail `json:"ratingOperator"`
+	Meta            Meta                   `json:"meta"`
+	Links           []Link                 `json:"links"`
+}
+
+// RatingOperatorDetail describes a rating comparison.
+//
+// RatingOperatorKey is the value expected by SearchParams.RatingOperatorKey.
+type RatingOperatorDetail struct {
+	RatingOperatorID   int            `json:"ratingOperatorId"`
+	RatingOperatorName string         `json:"ratingOperatorName"`
+	RatingOperatorKey  RatingOperator `json:"ratingOperatorKey"`
+	Links              []Link         `json:"links"`
+}
+
+// Get returns the details of all rating operators.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-RatingOperators
+func (s *RatingOperatorsService) Get() (*RatingOperators, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *RatingOperatorsService) GetContext(ctx context.Context) (*RatingOperators, error) {
+	var ratingOperators *RatingOperators
+	if err := s.client.get(ctx, "RatingOperators", &ratingOperators); err != nil {
+		return nil, err
+	}
+
+	return ratingOperators, nil
+}
@@ -0,0 +1,71 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRatingOperatorsGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "ratingOperator": [
+		{
+		  "ratingOperatorId": 1,
+		  "ratingOperatorName": "Less than or equal to",
+		  "ratingOperatorKey": "LessThanOrEqual",
+		  "links": []
+		},
+		{
+		  "ratingOperatorId": 2,
+		  "ratingOperatorName": "Equal to",
+		  "ratingOperatorKey": "Equal",
+		  "links": []
+		}
+	  ],
+	  "meta": { "itemCount": 2, "returncode": "OK" },
+	  "links": []
+	}`
+
+	expected := &RatingOperators{
+		RatingOperators: []RatingOperatorDetail{
+			{
+				RatingOperatorID:   1,
+				RatingOperatorName: "Less than or equal to",
+				RatingOperatorKey:  "LessThanOrEqual",
+				Links:              []Link{},
+			},
+			{
+				RatingOperatorID:   2,
+				RatingOperatorName: "Equal to",
+				RatingOperatorKey:  "Equal",
+				Links:              []Link{},
+			},
+		},
+		Meta:  Meta{ItemCount: 2, Returncode: "OK"},
+		Links: []Link{},
+	}
+
+	router.GET("/RatingOperators", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.RatingOperators.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
@@ -1,5 +1,9 @@
 package fhrs
 
+import (
+	"context"
+)
+
 // RatingsService encapsulates the Ratings methods of the API.
 //
 // https://api.ratings.food.gov.uk/help#Ratings
@@ -26,8 +30,13 @@ type Rating struct {
 //
 // https://api.ratings.food.gov.uk/Help/Api/GET-Ratings
 func (s *RatingsService) Get() (*Ratings, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *RatingsService) GetContext(ctx context.Context) (*Ratings, error) {
 	var ratings *Ratings
-	if err := s.client.get("Ratings", &ratings); err != nil {
+	if err := s.client.get(ctx, "Ratings", &ratings); err != nil {
 		return nil, err
 	}
 
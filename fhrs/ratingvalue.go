@@ -0,0 +1,228 @@
+package fhrs
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RatingStatus is whether an establishment has been given a rating and, if not,
+// why not.
+type RatingStatus int
+
+const (
+	RatingStatusUnknown             RatingStatus = iota // The value was not recognised.
+	RatingStatusRated                                   // The establishment has a score.
+	RatingStatusAwaitingInspection                      // The establishment has not been inspected yet.
+	RatingStatusAwaitingPublication                     // The rating has not been published yet.
+	RatingStatusExempt                                  // The establishment is not rated.
+)
+
+func (s RatingStatus) String() string {
+	return []string{
+		"unknown",
+		"rated",
+		"awaiting inspection",
+		"awaiting publication",
+		"exempt",
+	}[s]
+}
+
+// languageSuffix matches the language at the end of a rating key, such as the
+// "_en-gb" in "fhrs_3_en-gb".
+var languageSuffix = regexp.MustCompile(`_[a-z]{2}-[a-z]{2}$`)
+
+// RatingValue is a parsed hygiene rating, as given by the RatingValue and
+// RatingKey of an establishment.
+//
+// Ratings in the same scheme can be compared by Score. FHRS scores run from 0
+// to 5, while FHIS scores are 0 for "Improvement Required", 1 for "Pass" and
+// 2 for "Pass and Eat Safe".
+type RatingValue struct {
+	Raw    string       // The value as returned by the API, such as "5" or "Pass".
+	Key    string       // A language independent key, such as "fhrs_5" or "fhis_passandeatsafe".
+	Scheme SchemeType   // Empty if the scheme could not be determined.
+	Status RatingStatus // Whether there is a Score.
+	Score  int          // Only meaningful when Status is RatingStatusRated.
+}
+
+// ParseRatingValue parses a rating from its value and key, either of which may
+// be empty. The key is preferred as it names the scheme.
+func ParseRatingValue(value, key string) RatingValue {
+	r := RatingValue{Raw: value}
+
+	key = languageSuffix.ReplaceAllString(strings.ToLower(key), "")
+	token := normaliseRatingToken(value)
+
+	switch {
+	case strings.HasPrefix(key, "fhrs_"):
+		r.Scheme = SchemeTypeFHRS
+		token = normaliseRatingToken(strings.TrimPrefix(key, "fhrs_"))
+	case strings.HasPrefix(key, "fhis_"):
+		r.Scheme = SchemeTypeFHIS
+		token = normaliseRatingToken(strings.TrimPrefix(key, "fhis_"))
+	}
+
+	switch token {
+	case "0", "1", "2", "3", "4", "5":
+		r.Status = RatingStatusRated
+		r.Score, _ = strconv.Atoi(token)
+		r.Scheme = SchemeTypeFHRS
+	case "improvementrequired":
+		r.Status = RatingStatusRated
+		r.Score = 0
+		r.Scheme = SchemeTypeFHIS
+	case "pass":
+		r.Status = RatingStatusRated
+		r.Score = 1
+		r.Scheme = SchemeTypeFHIS
+	case "passandeatsafe":
+		r.Status = RatingStatusRated
+		r.Score = 2
+		r.Scheme = SchemeTypeFHIS
+	case "awaitinginspection":
+		r.Status = RatingStatusAwaitingInspection
+	case "awaitingpublication":
+		r.Status = RatingStatusAwaitingPublication
+	case "exempt":
+		r.Status = RatingStatusExempt
+	}
+
+	// The key is built the same way whichever of value and key were given,
+	// so it can be compared. It only lacks the scheme if that is unknown.
+	r.Key = token
+	if r.Scheme != "" && token != "" {
+		r.Key = strings.ToLower(string(r.Scheme)) + "_" + token
+	}
+
+	return r
+}
+
+// normaliseRatingToken removes the differences in case and spacing between
+// rating values and keys, so "Awaiting Inspection", "AwaitingInspection" and
+// "awaiting_inspection" are the same.
+func normaliseRatingToken(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-':
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// Rated reports whether the rating has a score.
+func (r RatingValue) Rated() bool {
+	return r.Status == RatingStatusRated
+}
+
+// AtLeast reports whether the rating has a score of at least score.
+func (r RatingValue) AtLeast(score int) bool {
+	return r.Rated() && r.Score >= score
+}
+
+// Compare returns -1, 0 or 1 if r is lower than, the same as, or higher than
+// o. Unrated establishments are lower than rated ones, and as FHRS and FHIS
+// scores are not comparable, ratings are grouped by scheme before score.
+func (r RatingValue) Compare(o RatingValue) int {
+	switch {
+	case r.Rated() != o.Rated():
+		if r.Rated() {
+			return 1
+		}
+
+		return -1
+	case r.Scheme != o.Scheme:
+		return compareStrings(string(r.Scheme), string(o.Scheme))
+	case r.Rated() && r.Score != o.Score:
+		if r.Score > o.Score {
+			return 1
+		}
+
+		return -1
+	case r.Status != o.Status:
+		if r.Status > o.Status {
+			return 1
+		}
+
+		return -1
+	}
+
+	return 0
+}
+
+// Less reports whether r is lower than o, for use with sort.Slice.
+func (r RatingValue) Less(o RatingValue) bool {
+	return r.Compare(o) < 0
+}
+
+func (r RatingValue) String() string {
+	return r.Raw
+}
+
+// ratingValueJSON is the JSON encoding of a RatingValue.
+type ratingValueJSON struct {
+	Value string `json:"value"`
+	Key   string `json:"key,omitempty"`
+}
+
+// MarshalJSON encodes the rating as its value and key, such as
+// {"value":"Exempt","key":"fhis_exempt"}, so that it decodes to the same
+// RatingValue.
+func (r RatingValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ratingValueJSON{Value: r.Raw, Key: r.Key})
+}
+
+// UnmarshalJSON decodes a rating encoded by MarshalJSON, or a plain value as
+// returned by the API such as "5". A plain value has no key, so the scheme is
+// inferred from the value, which can't be done for values shared by both
+// schemes such as "Exempt".
+func (r *RatingValue) UnmarshalJSON(b []byte) error {
+	var v ratingValueJSON
+	if err := json.Unmarshal(b, &v); err == nil {
+		*r = ParseRatingValue(v.Value, v.Key)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		// Some values are numbers rather than strings.
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return err
+		}
+
+		s = n.String()
+	}
+
+	*r = ParseRatingValue(s, "")
+	return nil
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// Rating returns the parsed RatingValue and RatingKey of the establishment.
+func (e Establishment) Rating() RatingValue {
+	return ParseRatingValue(e.RatingValue, e.RatingKey)
+}
+
+// Rating returns the parsed RatingValue and RatingKey of the establishment.
+func (e BasicEstablishment) Rating() RatingValue {
+	return ParseRatingValue(e.RatingValue, e.RatingKey)
+}
+
+// Value returns the parsed RatingName and RatingKey of the rating.
+func (r Rating) Value() RatingValue {
+	return ParseRatingValue(r.RatingName, r.RatingKey)
+}
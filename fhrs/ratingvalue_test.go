@@ -0,0 +1,209 @@
+package fhrs
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseRatingValue(t *testing.T) {
+	cases := []struct {
+		value string
+		key   string
+		want  RatingValue
+	}{
+		{
+			value: "3",
+			key:   "fhrs_3_en-gb",
+			want:  RatingValue{Raw: "3", Key: "fhrs_3", Scheme: SchemeTypeFHRS, Status: RatingStatusRated, Score: 3},
+		},
+		{
+			value: "3",
+			key:   "fhrs_3_cy-gb",
+			want:  RatingValue{Raw: "3", Key: "fhrs_3", Scheme: SchemeTypeFHRS, Status: RatingStatusRated, Score: 3},
+		},
+		{
+			value: "0",
+			want:  RatingValue{Raw: "0", Key: "fhrs_0", Scheme: SchemeTypeFHRS, Status: RatingStatusRated, Score: 0},
+		},
+		{
+			value: "AwaitingInspection",
+			key:   "fhrs_awaitinginspection_en-GB",
+			want:  RatingValue{Raw: "AwaitingInspection", Key: "fhrs_awaitinginspection", Scheme: SchemeTypeFHRS, Status: RatingStatusAwaitingInspection},
+		},
+		{
+			value: "Exempt",
+			key:   "fhrs_exempt_en-gb",
+			want:  RatingValue{Raw: "Exempt", Key: "fhrs_exempt", Scheme: SchemeTypeFHRS, Status: RatingStatusExempt},
+		},
+		{
+			value: "AwaitingPublication",
+			key:   "fhrs_awaitingpublication_en-gb",
+			want:  RatingValue{Raw: "AwaitingPublication", Key: "fhrs_awaitingpublication", Scheme: SchemeTypeFHRS, Status: RatingStatusAwaitingPublication},
+		},
+		{
+			value: "Pass",
+			key:   "fhis_pass_en-gb",
+			want:  RatingValue{Raw: "Pass", Key: "fhis_pass", Scheme: SchemeTypeFHIS, Status: RatingStatusRated, Score: 1},
+		},
+		{
+			value: "Improvement Required",
+			key:   "fhis_improvement_required_en-GB",
+			want:  RatingValue{Raw: "Improvement Required", Key: "fhis_improvementrequired", Scheme: SchemeTypeFHIS, Status: RatingStatusRated, Score: 0},
+		},
+		{
+			value: "Pass and Eat Safe",
+			want:  RatingValue{Raw: "Pass and Eat Safe", Key: "fhis_passandeatsafe", Scheme: SchemeTypeFHIS, Status: RatingStatusRated, Score: 2},
+		},
+		{
+			value: "Awaiting Inspection",
+			key:   "fhis_awaiting_inspection_en-GB",
+			want:  RatingValue{Raw: "Awaiting Inspection", Key: "fhis_awaitinginspection", Scheme: SchemeTypeFHIS, Status: RatingStatusAwaitingInspection},
+		},
+		{
+			value: "Something new",
+			want:  RatingValue{Raw: "Something new", Key: "somethingnew"},
+		},
+		{
+			value: "AwaitingInspection",
+			want:  RatingValue{Raw: "AwaitingInspection", Key: "awaitinginspection", Status: RatingStatusAwaitingInspection},
+		},
+	}
+
+	for _, c := range cases {
+		have := ParseRatingValue(c.value, c.key)
+		if !reflect.DeepEqual(c.want, have) {
+			t.Errorf("Expected %q, %q to give:\n%+v\nBut got:\n%+v\n", c.value, c.key, c.want, have)
+		}
+	}
+}
+
+func TestParseRatingValue_Key(t *testing.T) {
+	// The same rating gives the same key from its value, its key or both.
+	// Values such as "Awaiting Inspection" are left out as they don't name
+	// their scheme.
+	cases := [][2]string{
+		{"Pass and Eat Safe", "fhis_pass_and_eat_safe_en-gb"},
+		{"Improvement Required", "fhis_improvement_required_cy-GB"},
+		{"3", "fhrs_3_cy-gb"},
+	}
+
+	for _, c := range cases {
+		fromValue := ParseRatingValue(c[0], "").Key
+		fromKey := ParseRatingValue("", c[1]).Key
+		fromBoth := ParseRatingValue(c[0], c[1]).Key
+
+		if fromValue == "" || fromValue != fromKey || fromKey != fromBoth {
+			t.Errorf("Expected the same key for %q and %q but got %q, %q and %q", c[0], c[1], fromValue, fromKey, fromBoth)
+		}
+	}
+}
+
+func TestRatingValueCompare(t *testing.T) {
+	ratings := []RatingValue{
+		ParseRatingValue("5", "fhrs_5_en-gb"),
+		ParseRatingValue("Exempt", "fhrs_exempt_en-gb"),
+		ParseRatingValue("1", "fhrs_1_en-gb"),
+		ParseRatingValue("Pass", "fhis_pass_en-gb"),
+		ParseRatingValue("AwaitingInspection", "fhrs_awaitinginspection_en-gb"),
+		ParseRatingValue("Improvement Required", "fhis_improvement_required_en-gb"),
+		ParseRatingValue("3", "fhrs_3_en-gb"),
+	}
+
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].Less(ratings[j])
+	})
+
+	var order []string
+	for _, r := range ratings {
+		order = append(order, r.String())
+	}
+
+	expected := []string{"AwaitingInspection", "Exempt", "Improvement Required", "Pass", "1", "3", "5"}
+	if !reflect.DeepEqual(expected, order) {
+		t.Errorf("Expected order %v but got %v", expected, order)
+	}
+
+	if c := ParseRatingValue("4", "").Compare(ParseRatingValue("4", "fhrs_4_cy-gb")); c != 0 {
+		t.Errorf("Expected the same rating in different languages to be equal but got %d", c)
+	}
+
+	if !ParseRatingValue("4", "").AtLeast(4) {
+		t.Error("Expected 4 to be at least 4")
+	}
+
+	if ParseRatingValue("Exempt", "").AtLeast(0) {
+		t.Error("Expected Exempt not to be at least 0")
+	}
+}
+
+func TestRatingValueJSON(t *testing.T) {
+	var e struct {
+		Rating RatingValue `json:"RatingValue"`
+	}
+
+	if err := json.Unmarshal([]byte(`{ "RatingValue": "Pass" }`), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Rating.Scheme != SchemeTypeFHIS || e.Rating.Score != 1 {
+		t.Errorf("Expected an FHIS pass but got %+v", e.Rating)
+	}
+
+	if err := json.Unmarshal([]byte(`{ "RatingValue": 4 }`), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if !e.Rating.AtLeast(4) {
+		t.Errorf("Expected a numeric value to be parsed but got %+v", e.Rating)
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"RatingValue":{"value":"4","key":"fhrs_4"}}` {
+		t.Errorf("Expected the value and key to be encoded but got %s", b)
+	}
+}
+
+func TestRatingValueJSON_RoundTrip(t *testing.T) {
+	ratings := []RatingValue{
+		ParseRatingValue("5", "fhrs_5_en-gb"),
+		ParseRatingValue("Exempt", "fhis_exempt_en-GB"),
+		ParseRatingValue("Awaiting Inspection", "fhis_awaiting_inspection_en-GB"),
+		ParseRatingValue("AwaitingPublication", "fhrs_awaitingpublication_cy-gb"),
+		ParseRatingValue("Pass and Eat Safe", ""),
+		ParseRatingValue("Something new", ""),
+	}
+
+	for _, want := range ratings {
+		b, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var have RatingValue
+		if err := json.Unmarshal(b, &have); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(want, have) {
+			t.Errorf("Expected %s to decode to:\n%+v\nBut got:\n%+v\n", b, want, have)
+		}
+	}
+}
+
+func TestEstablishmentRating(t *testing.T) {
+	e := Establishment{RatingValue: "3", RatingKey: "fhrs_3_en-gb"}
+	if r := e.Rating(); r.Score != 3 || r.Key != "fhrs_3" {
+		t.Errorf("Expected a rating of 3 but got %+v", r)
+	}
+
+	r := Rating{RatingName: "Pass and Eat Safe", RatingKey: "fhis_pass_and_eat_safe_en-GB"}
+	if v := r.Value(); v.Score != 2 || v.Scheme != SchemeTypeFHIS {
+		t.Errorf("Expected an FHIS rating of 2 but got %+v", v)
+	}
+}
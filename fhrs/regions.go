@@ -0,0 +1,114 @@
+package fhrs
+
+import (
+	"context"
+	"fmt"
+)
+
+// RegionsService encapsulates the Regions methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#Regions
+type RegionsService service
+
+// Regions is a list of regions.
+type Regions struct {
+	Regions []Region `json:"regions"`
+	Meta    Meta     `json:"meta"`
+	Links   []Link   `json:"links"`
+}
+
+// Region is a geographical grouping of local authorities, as seen in
+// Authority.RegionName. The basic endpoints return the same fields without
+// Links.
+type Region struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	NameKey string `json:"nameKey"`
+	Code    string `json:"code"`
+	Links   []Link `json:"links"`
+}
+
+// Get returns the details of all regions.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Regions
+func (s *RegionsService) Get() (*Regions, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *RegionsService) GetContext(ctx context.Context) (*Regions, error) {
+	var regions *Regions
+	if err := s.client.get(ctx, "Regions", &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+// GetPage returns a single page of regions.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Regions-pageNumber-pageSize
+func (s *RegionsService) GetPage(pageNumber, pageSize int) (*Regions, error) {
+	return s.GetPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// GetPageContext is like GetPage but uses ctx for the request.
+func (s *RegionsService) GetPageContext(ctx context.Context, pageNumber, pageSize int) (*Regions, error) {
+	var regions *Regions
+	if err := s.client.get(ctx, fmt.Sprintf("Regions/%d/%d", pageNumber, pageSize), &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+// GetByID returns the region with the given ID.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Regions-id
+func (s *RegionsService) GetByID(id string) (*Region, error) {
+	return s.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but uses ctx for the request.
+func (s *RegionsService) GetByIDContext(ctx context.Context, id string) (*Region, error) {
+	var region *Region
+	if err := s.client.get(ctx, fmt.Sprintf("Regions/%s", id), &region); err != nil {
+		return nil, err
+	}
+
+	return region, nil
+}
+
+// Basic returns all regions in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Regions-basic
+func (s *RegionsService) Basic() (*Regions, error) {
+	return s.BasicContext(context.Background())
+}
+
+// BasicContext is like Basic but uses ctx for the request.
+func (s *RegionsService) BasicContext(ctx context.Context) (*Regions, error) {
+	var regions *Regions
+	if err := s.client.get(ctx, "Regions/basic", &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+// BasicPage returns a single page of regions in the basic format.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-Regions-basic-pageNumber-pageSize
+func (s *RegionsService) BasicPage(pageNumber, pageSize int) (*Regions, error) {
+	return s.BasicPageContext(context.Background(), pageNumber, pageSize)
+}
+
+// BasicPageContext is like BasicPage but uses ctx for the request.
+func (s *RegionsService) BasicPageContext(ctx context.Context, pageNumber, pageSize int) (*Regions, error) {
+	var regions *Regions
+	if err := s.client.get(ctx, fmt.Sprintf("Regions/basic/%d/%d", pageNumber, pageSize), &regions); err != nil {
+		return nil, err
+	}
+
+	return regions, nil
+}
@@ -0,0 +1,174 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy is a reasonable policy for long running jobs. It makes up
+// to 4 attempts, waiting around 0.5s, 1s and 2s between them.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryPolicy controls how failed requests are retried. The zero value makes a
+// single attempt, which is the Client's default.
+//
+// Only idempotent requests are retried, and only when they fail with a
+// connection error or an APIError for which IsRetryable is true.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles for each
+	// retry after that, up to MaxBackoff, which should be set if there are
+	// many attempts as otherwise the delay grows without limit. A request is
+	// not retried if the server asks, with Retry-After, for a longer wait
+	// than MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of each delay, between 0 and 1, which is
+	// randomised so that many clients don't retry in lockstep.
+	Jitter float64
+
+	// OnRetry is called, if set, before waiting to make each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt which is about to be retried.
+type RetryEvent struct {
+	Method  string
+	URL     string
+	Attempt int // The attempt which failed, starting from 1.
+	Err     error
+	Delay   time.Duration // How long until the next attempt.
+}
+
+// WithRetryPolicy sets how failed requests are retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) error {
+		if p.MinBackoff < 0 || p.MaxBackoff < 0 {
+			return errors.New("Backoff must not be negative")
+		}
+
+		if p.Jitter < 0 || p.Jitter > 1 {
+			return errors.New("Jitter must be between 0 and 1")
+		}
+
+		c.retryPolicy = p
+		return nil
+	}
+}
+
+// delay returns how long to wait before retrying after the given attempt
+// failed with err, and false if it should not be retried.
+func (p RetryPolicy) delay(method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !isIdempotent(method) || !isRetryable(err) {
+		return 0, false
+	}
+
+	// The server knows best, so a Retry-After overrides our backoff. Waiting
+	// longer than MaxBackoff isn't worth it, and retrying sooner than asked
+	// would likely fail again, so give up instead.
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		if d, ok := retryAfter(apiErr.Header, time.Now()); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				return 0, false
+			}
+
+			return d, true
+		}
+	}
+
+	// Without a MaxBackoff, stop doubling before the delay overflows.
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || backoff < p.MaxBackoff) && backoff <= math.MaxInt64/2; i++ {
+		backoff *= 2
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		backoff -= time.Duration(p.Jitter * rand.Float64() * float64(backoff))
+	}
+
+	return backoff, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// isRetryable reports whether err is worth retrying. Anything other than an
+// APIError is a failure to get a response at all, such as a reset connection,
+// so is retried unless the caller gave up.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRetryable()
+	}
+
+	return true
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
+
+// sleep waits for d to pass or ctx to be done, whichever is first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,290 @@
+package fhrs
+
+import (
+	"context"
+	"errors"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	var events []RetryEvent
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		OnRetry: func(e RetryEvent) {
+			events = append(events, e)
+		},
+	}
+
+	if err := WithRetryPolicy(policy)(client); err != nil {
+		t.Error(err)
+	}
+
+	calls := 0
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, "The service is unavailable.")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{ "ratings": [ { "ratingId": 12 } ] }`)
+	})
+
+	ratings, err := client.Ratings.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ratings.Ratings) != 1 {
+		t.Errorf("Expected 1 rating but got %d", len(ratings.Ratings))
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 calls but got %d", calls)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 retry events but got %d", len(events))
+	}
+
+	for i, e := range events {
+		if e.Attempt != i+1 {
+			t.Errorf("Expected attempt to be %d but got %d", i+1, e.Attempt)
+		}
+
+		if !errors.As(e.Err, &APIError{}) {
+			t.Errorf("Expected event error to be APIError but type is %T", e.Err)
+		}
+	}
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	if err := WithRetryPolicy(RetryPolicy{MaxAttempts: 2})(client); err != nil {
+		t.Error(err)
+	}
+
+	calls := map[string]int{}
+	router.GET("/Establishments/:id", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+		calls[id]++
+
+		w.Header().Set("Content-Type", "application/json")
+		if id == "400" {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	_, err = client.Establishments.GetByID("400")
+	if err == nil {
+		t.Error("Expected an error to be returned but got nil")
+	}
+
+	if calls["400"] != 1 {
+		t.Errorf("Expected a 400 not to be retried but got %d calls", calls["400"])
+	}
+
+	_, err = client.Establishments.GetByID("503")
+	if err == nil {
+		t.Error("Expected an error to be returned but got nil")
+	}
+
+	if calls["503"] != 2 {
+		t.Errorf("Expected a 503 to be attempted twice but got %d calls", calls["503"])
+	}
+}
+
+func TestRetry_BodyReadError(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	if err := WithRetryPolicy(RetryPolicy{MaxAttempts: 2})(client); err != nil {
+		t.Error(err)
+	}
+
+	calls := 0
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls++
+		body := `{ "ratings": [ { "ratingId": 12 } ] }`
+
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			// Drop the connection part way through the body.
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			io.WriteString(w, body[:10])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+
+		io.WriteString(w, body)
+	})
+
+	ratings, err := client.Ratings.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ratings.Ratings) != 1 {
+		t.Errorf("Expected 1 rating but got %d", len(ratings.Ratings))
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls but got %d", calls)
+	}
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	if err := WithRetryPolicy(RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour})(client); err != nil {
+		t.Error(err)
+	}
+
+	router.GET("/Ratings", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Ratings.GetContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected err to be context.DeadlineExceeded but got %v", err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  300 * time.Millisecond,
+	}
+
+	unavailable := APIError{StatusCode: http.StatusServiceUnavailable}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 3, want: 300 * time.Millisecond},
+		{attempt: 4, want: 300 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		d, ok := p.delay(http.MethodGet, c.attempt, unavailable)
+		if !ok || d != c.want {
+			t.Errorf("Expected attempt %d to wait %s but got %s (retry: %t)", c.attempt, c.want, d, ok)
+		}
+	}
+
+	if _, ok := p.delay(http.MethodGet, 5, unavailable); ok {
+		t.Error("Expected no retry after MaxAttempts")
+	}
+
+	if _, ok := p.delay(http.MethodPost, 1, unavailable); ok {
+		t.Error("Expected a POST not to be retried")
+	}
+
+	limited := APIError{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+
+	if _, ok := p.delay(http.MethodGet, 1, limited); ok {
+		t.Error("Expected no retry when Retry-After is longer than MaxBackoff")
+	}
+
+	patient := RetryPolicy{MaxAttempts: 2, MaxBackoff: 10 * time.Second}
+	if d, ok := patient.delay(http.MethodGet, 1, limited); !ok || d != 7*time.Second {
+		t.Errorf("Expected Retry-After to give 7s but got %s (retry: %t)", d, ok)
+	}
+
+	unlimited := RetryPolicy{MaxAttempts: 2}
+	if d, ok := unlimited.delay(http.MethodGet, 1, limited); !ok || d != 7*time.Second {
+		t.Errorf("Expected Retry-After to give 7s without MaxBackoff but got %s (retry: %t)", d, ok)
+	}
+
+	// Without a MaxBackoff the delay keeps growing but never overflows.
+	unbounded := RetryPolicy{MaxAttempts: 100, MinBackoff: time.Second}
+	previous := time.Duration(0)
+	for attempt := 1; attempt < 100; attempt++ {
+		d, ok := unbounded.delay(http.MethodGet, attempt, unavailable)
+		if !ok || d < previous {
+			t.Fatalf("Expected attempt %d to wait at least %s but got %s (retry: %t)", attempt, previous, d, ok)
+		}
+
+		previous = d
+	}
+
+	jittered := RetryPolicy{MaxAttempts: 2, MinBackoff: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d, _ := jittered.delay(http.MethodGet, 1, unavailable)
+		if d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("Expected jittered delay to be between 0.5s and 1s but got %s", d)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 2, 3, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "120", want: 2 * time.Minute, ok: true},
+		{value: "-1", ok: false},
+		{value: "Mon, 03 Feb 2020 12:00:30 GMT", want: 30 * time.Second, ok: true},
+		{value: "Mon, 03 Feb 2020 11:00:00 GMT", want: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+
+	for _, c := range cases {
+		d, ok := retryAfter(http.Header{"Retry-After": []string{c.value}}, now)
+		if d != c.want || ok != c.ok {
+			t.Errorf("Expected Retry-After %q to give %s, %t but got %s, %t", c.value, c.want, c.ok, d, ok)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package fhrs
+
+import (
+	"context"
+)
+
+// SchemeTypesService encapsulates the SchemeTypes methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#SchemeTypes
+type SchemeTypesService service
+
+// SchemeTypes is the list of rating schemes.
+type SchemeTypes struct {
+	SchemeTypes []SchemeTypeDetail `json:"schemeTypes"`
+	Meta        Meta               `json:"meta"`
+	Links       []Link             `json:"links"`
+}
+
+// SchemeTypeDetail describes a rating scheme.
+//
+// SchemeTypeKey is the value expected by SearchParams.SchemeTypeKey.
+type SchemeTypeDetail struct {
+	SchemeTypeID   int        `json:"schemeTypeid"`
+	SchemeTypeName string     `json:"schemeTypeName"`
+	SchemeTypeKey  SchemeType `json:"schemeTypeKey"`
+	Links          []Link     `json:"links"`
+}
+
+// Get returns the details of all rating schemes.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-SchemeTypes
+func (s *SchemeTypesService) Get() (*SchemeTypes, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *SchemeTypesService) GetContext(ctx context.Context) (*SchemeTypes, error) {
+	var schemeTypes *SchemeTypes
+	if err := s.client.get(ctx, "SchemeTypes", &schemeTypes); err != nil {
+		return nil, err
+	}
+
+	return schemeTypes, nil
+}
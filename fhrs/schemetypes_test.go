@@ -0,0 +1,81 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSchemeTypesGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "schemeTypes": [
+		{
+		  "schemeTypeid": 1,
+		  "schemeTypeName": "Food Hygiene Rating Scheme",
+		  "schemeTypeKey": "FHRS",
+		  "links": []
+		},
+		{
+		  "schemeTypeid": 2,
+		  "schemeTypeName": "Food Hygiene Information Scheme",
+		  "schemeTypeKey": "FHIS",
+		  "links": []
+		}
+	  ],
+	  "meta": { "itemCount": 2, "returncode": "OK" },
+	  "links": [
+		{
+		  "rel": "self",
+		  "href": "http://api.ratings.food.gov.uk/schemetypes"
+		}
+	  ]
+	}`
+
+	expected := &SchemeTypes{
+		SchemeTypes: []SchemeTypeDetail{
+			{
+				SchemeTypeID:   1,
+				SchemeTypeName: "Food Hygiene Rating Scheme",
+				SchemeTypeKey:  "FHRS",
+				Links:          []Link{},
+			},
+			{
+				SchemeTypeID:   2,
+				SchemeTypeName: "Food Hygiene Information Scheme",
+				SchemeTypeKey:  "FHIS",
+				Links:          []Link{},
+			},
+		},
+		Meta: Meta{ItemCount: 2, Returncode: "OK"},
+		Links: []Link{
+			{
+				Rel:  "self",
+				Href: "http://api.ratings.food.gov.uk/schemetypes",
+			},
+		},
+	}
+
+	router.GET("/SchemeTypes", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.SchemeTypes.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
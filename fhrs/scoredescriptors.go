@@ -0,0 +1,53 @@
+package fhrs
+
+import (
+	"context"
+	"net/url"
+)
+
+// ScoreDescriptorsService encapsulates the ScoreDescriptors methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#ScoreDescriptors
+type ScoreDescriptorsService service
+
+// ScoreDescriptors is the list of descriptions for an establishment's scores.
+type ScoreDescriptors struct {
+	ScoreDescriptors []ScoreDescriptor `json:"scoreDescriptors"`
+	Meta             Meta              `json:"meta"`
+	Links            []Link            `json:"links"`
+}
+
+// ScoreDescriptor is the human readable description of a value in Scores.
+//
+// ScoreCategory is one of "Hygiene", "Structural" or "Confidence", matching
+// the fields of Scores.
+type ScoreDescriptor struct {
+	ID            int    `json:"Id"`
+	ScoreCategory string `json:"ScoreCategory"`
+	Score         int    `json:"Score"`
+	Description   string `json:"Description"`
+	Links         []Link `json:"links"`
+}
+
+// Get returns the score descriptions for the establishment with the given
+// FHRSID.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-ScoreDescriptors_establishmentId
+func (s *ScoreDescriptorsService) Get(establishmentID string) (*ScoreDescriptors, error) {
+	return s.GetContext(context.Background(), establishmentID)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *ScoreDescriptorsService) GetContext(ctx context.Context, establishmentID string) (*ScoreDescriptors, error) {
+	var scoreDescriptors *ScoreDescriptors
+	u := url.URL{Path: "ScoreDescriptors"}
+	q := u.Query()
+	q.Set("establishmentId", establishmentID)
+	u.RawQuery = q.Encode()
+
+	if err := s.client.get(ctx, u.String(), &scoreDescriptors); err != nil {
+		return nil, err
+	}
+
+	return scoreDescriptors, nil
+}
@@ -0,0 +1,80 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestScoreDescriptorsGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	idQuery := "82940"
+	body := `{
+	  "scoreDescriptors": [
+		{
+		  "Id": 1,
+		  "ScoreCategory": "Hygiene",
+		  "Score": 5,
+		  "Description": "Good",
+		  "links": []
+		},
+		{
+		  "Id": 9,
+		  "ScoreCategory": "Structural",
+		  "Score": 10,
+		  "Description": "Generally satisfactory",
+		  "links": []
+		}
+	  ],
+	  "meta": { "itemCount": 2, "returncode": "OK" },
+	  "links": []
+	}`
+
+	expected := &ScoreDescriptors{
+		ScoreDescriptors: []ScoreDescriptor{
+			{
+				ID:            1,
+				ScoreCategory: "Hygiene",
+				Score:         5,
+				Description:   "Good",
+				Links:         []Link{},
+			},
+			{
+				ID:            9,
+				ScoreCategory: "Structural",
+				Score:         10,
+				Description:   "Generally satisfactory",
+				Links:         []Link{},
+			},
+		},
+		Meta:  Meta{ItemCount: 2, Returncode: "OK"},
+		Links: []Link{},
+	}
+
+	router.GET("/ScoreDescriptors", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		if q := r.URL.Query().Get("establishmentId"); q != idQuery {
+			t.Errorf("Expected param 'establishmentId' to equal %s but got %s", idQuery, q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.ScoreDescriptors.Get(idQuery)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
@@ -0,0 +1,194 @@
+package fhrs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPageSize is the largest page size the API accepts.
+const MaxPageSize = 5000
+
+// Validate checks that the parameters make sense together, returning an error
+// describing every problem found. The API tends to ignore invalid parameters
+// rather than reject them, so this catches mistakes which would otherwise
+// silently widen a search.
+func (p *SearchParams) Validate() error {
+	return invalidSearch(p.problems())
+}
+
+// problems returns a description of each problem with the parameters.
+func (p *SearchParams) problems() []string {
+	var problems []string
+
+	switch {
+	case (p.Latitude == nil) != (p.Longitude == nil):
+		problems = append(problems, "latitude and longitude must be given together")
+	case p.Latitude != nil && !inUK(*p.Latitude, *p.Longitude):
+		problems = append(problems, fmt.Sprintf("location %v, %v is outside the UK", *p.Latitude, *p.Longitude))
+	}
+
+	if p.MaxDistanceLimit != nil {
+		if p.Latitude == nil || p.Longitude == nil {
+			problems = append(problems, "max distance limit needs a latitude and longitude")
+		}
+
+		if *p.MaxDistanceLimit <= 0 {
+			problems = append(problems, "max distance limit must be positive")
+		}
+	}
+
+	if p.RatingOperatorKey != "" {
+		if !p.RatingOperatorKey.Known() {
+			problems = append(problems, "unknown rating operator "+strconv.Quote(string(p.RatingOperatorKey)))
+		}
+
+		if p.RatingKey == "" {
+			problems = append(problems, "rating operator needs a rating key")
+		}
+	}
+
+	if p.PageNumber != nil && *p.PageNumber < 1 {
+		problems = append(problems, "page number must be at least 1")
+	}
+
+	if p.PageSize != nil && (*p.PageSize < 1 || *p.PageSize > MaxPageSize) {
+		problems = append(problems, "page size must be between 1 and "+strconv.Itoa(MaxPageSize))
+	}
+
+	return problems
+}
+
+// invalidSearch returns an error listing problems, or nil if there are none.
+func invalidSearch(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.New("Invalid search: " + strings.Join(problems, "; "))
+}
+
+// SearchBuilder builds validated SearchParams.
+//
+//	params, err := fhrs.NewSearch().
+//		Name("pizza").
+//		Near(50.7984, -1.0916, 2).
+//		RatingAtLeast(4).
+//		Build()
+//	if err != nil {
+//		// Handle err
+//	}
+//
+//	establishments, err := client.Establishments.Search(params)
+type SearchBuilder struct {
+	params   SearchParams
+	problems []string
+}
+
+// NewSearch returns an empty SearchBuilder.
+func NewSearch() *SearchBuilder {
+	return &SearchBuilder{}
+}
+
+// Name matches establishments by business name.
+func (b *SearchBuilder) Name(name string) *SearchBuilder {
+	b.params.Name = name
+	return b
+}
+
+// Address matches establishments by address or postcode.
+func (b *SearchBuilder) Address(address string) *SearchBuilder {
+	b.params.Address = address
+	return b
+}
+
+// Near matches establishments within miles of the given location.
+func (b *SearchBuilder) Near(latitude, longitude float64, miles int) *SearchBuilder {
+	b.params.Latitude = &latitude
+	b.params.Longitude = &longitude
+	b.params.MaxDistanceLimit = &miles
+	return b
+}
+
+// BusinessType matches establishments of the given BusinessType.BusinessTypeID.
+func (b *SearchBuilder) BusinessType(id int) *SearchBuilder {
+	b.params.BusinessTypeID = strconv.Itoa(id)
+	return b
+}
+
+// Scheme matches establishments rated under the given scheme.
+func (b *SearchBuilder) Scheme(schemeTypeKey SchemeType) *SearchBuilder {
+	b.params.SchemeTypeKey = schemeTypeKey
+	return b
+}
+
+// RatingEquals matches establishments with exactly the given FHRS score.
+func (b *SearchBuilder) RatingEquals(score int) *SearchBuilder {
+	return b.rating(score, RatingOperatorEqual)
+}
+
+// RatingAtLeast matches establishments with at least the given FHRS score.
+func (b *SearchBuilder) RatingAtLeast(score int) *SearchBuilder {
+	return b.rating(score, RatingOperatorGreaterThanOrEqual)
+}
+
+// RatingAtMost matches establishments with at most the given FHRS score.
+func (b *SearchBuilder) RatingAtMost(score int) *SearchBuilder {
+	return b.rating(score, RatingOperatorLessThanOrEqual)
+}
+
+// Rating matches establishments by rating key, such as "Exempt" or
+// "AwaitingInspection", with no comparison.
+func (b *SearchBuilder) Rating(ratingKey string) *SearchBuilder {
+	b.params.RatingKey = ratingKey
+	b.params.RatingOperatorKey = ""
+	return b
+}
+
+func (b *SearchBuilder) rating(score int, operator RatingOperator) *SearchBuilder {
+	if score < 0 || score > 5 {
+		b.problems = append(b.problems, "rating must be between 0 and 5")
+	}
+
+	b.params.RatingKey = strconv.Itoa(score)
+	b.params.RatingOperatorKey = operator
+	return b
+}
+
+// InAuthority matches establishments inspected by the given
+// Authority.LocalAuthorityID.
+func (b *SearchBuilder) InAuthority(id int) *SearchBuilder {
+	b.params.LocalAuthorityID = strconv.Itoa(id)
+	return b
+}
+
+// InCountry matches establishments in the given Country.ID.
+func (b *SearchBuilder) InCountry(id int) *SearchBuilder {
+	b.params.CountryID = strconv.Itoa(id)
+	return b
+}
+
+// SortBy orders the results.
+func (b *SearchBuilder) SortBy(sortOptionKey SortOption) *SearchBuilder {
+	b.params.SortOptionKey = sortOptionKey
+	return b
+}
+
+// Page requests a single page of results.
+func (b *SearchBuilder) Page(pageNumber, pageSize int) *SearchBuilder {
+	b.params.PageNumber = &pageNumber
+	b.params.PageSize = &pageSize
+	return b
+}
+
+// Build validates the search and returns its SearchParams.
+func (b *SearchBuilder) Build() (*SearchParams, error) {
+	params := b.params
+	problems := append(append([]string{}, b.problems...), params.problems()...)
+	if err := invalidSearch(problems); err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
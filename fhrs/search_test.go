@@ -0,0 +1,94 @@
+package fhrs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchBuilder(t *testing.T) {
+	params, err := NewSearch().
+		Name("pizza").
+		Address("Portsmouth").
+		Near(50.7984, -1.0916, 2).
+		BusinessType(1).
+		Scheme("FHRS").
+		RatingAtLeast(4).
+		InAuthority(197).
+		InCountry(1).
+		SortBy("rating").
+		Page(2, 50).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if params.Name != "pizza" || params.Address != "Portsmouth" {
+		t.Errorf("Expected name and address to be set but got %+v", params)
+	}
+
+	if *params.Latitude != 50.7984 || *params.Longitude != -1.0916 || *params.MaxDistanceLimit != 2 {
+		t.Errorf("Expected location to be set but got %v, %v, %v",
+			*params.Latitude, *params.Longitude, *params.MaxDistanceLimit)
+	}
+
+	if params.RatingKey != "4" || params.RatingOperatorKey != "GreaterThanOrEqual" {
+		t.Errorf("Expected rating of at least 4 but got %s %s", params.RatingOperatorKey, params.RatingKey)
+	}
+
+	if params.BusinessTypeID != "1" || params.LocalAuthorityID != "197" || params.CountryID != "1" {
+		t.Errorf("Expected IDs to be set but got %+v", params)
+	}
+
+	if params.SchemeTypeKey != "FHRS" || params.SortOptionKey != "rating" {
+		t.Errorf("Expected scheme and sort to be set but got %+v", params)
+	}
+
+	if *params.PageNumber != 2 || *params.PageSize != 50 {
+		t.Errorf("Expected page 2 of 50 but got %d of %d", *params.PageNumber, *params.PageSize)
+	}
+}
+
+func TestSearchBuilder_Invalid(t *testing.T) {
+	cases := map[string]*SearchBuilder{
+		"rating must be between 0 and 5":       NewSearch().RatingAtLeast(6),
+		"outside the UK":                       NewSearch().Near(48.8566, 2.3522, 1),
+		"max distance limit must be positive":  NewSearch().Near(50.7984, -1.0916, 0),
+		"page number must be at least 1":       NewSearch().Page(0, 10),
+		"page size must be between 1 and 5000": NewSearch().Page(1, MaxPageSize+1),
+	}
+
+	for want, b := range cases {
+		_, err := b.Build()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q but got %v", want, err)
+		}
+	}
+
+	_, err := NewSearch().RatingAtMost(-1).Page(0, 0).Build()
+	if err == nil || strings.Count(err.Error(), ";") != 2 {
+		t.Errorf("Expected all three problems to be reported but got %v", err)
+	}
+}
+
+func TestSearchParamsValidate(t *testing.T) {
+	lat := 50.7984
+	distance := 5
+
+	cases := map[string]*SearchParams{
+		"latitude and longitude must be given together": {Latitude: &lat},
+		"max distance limit needs a latitude":           {MaxDistanceLimit: &distance},
+		`unknown rating operator "GreaterThan"`:         {RatingKey: "4", RatingOperatorKey: "GreaterThan"},
+		"rating operator needs a rating key":            {RatingOperatorKey: "Equal"},
+	}
+
+	for want, params := range cases {
+		err := params.Validate()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q but got %v", want, err)
+		}
+	}
+
+	if err := (&SearchParams{Name: "pizza"}).Validate(); err != nil {
+		t.Errorf("Expected a name search to be valid but got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package fhrs
+
+import (
+	"context"
+)
+
+// SortOptionsService encapsulates the SortOptions methods of the API.
+//
+// https://api.ratings.food.gov.uk/help#SortOptions
+type SortOptionsService service
+
+// SortOptions is the list of ways search results can be ordered.
+type SortOptions struct {
+	SortOptions []SortOptionDetail `json:"sortOptions"`
+	Meta        Meta               `json:"meta"`
+	Links       []Link             `json:"links"`
+}
+
+// SortOptionDetail describes a search ordering.
+//
+// SortOptionKey is the value expected by SearchParams.SortOptionKey.
+type SortOptionDetail struct {
+	SortOptionID   int        `json:"sortOptionId"`
+	SortOptionName string     `json:"sortOptionName"`
+	SortOptionKey  SortOption `json:"sortOptionKey"`
+	Links          []Link     `json:"links"`
+}
+
+// Get returns the details of all sort options.
+//
+// https://api.ratings.food.gov.uk/Help/Api/GET-SortOptions
+func (s *SortOptionsService) Get() (*SortOptions, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *SortOptionsService) GetContext(ctx context.Context) (*SortOptions, error) {
+	var sortOptions *SortOptions
+	if err := s.client.get(ctx, "SortOptions", &sortOptions); err != nil {
+		return nil, err
+	}
+
+	return sortOptions, nil
+}
@@ -0,0 +1,71 @@
+package fhrs
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSortOptionsGet(t *testing.T) {
+	client, server, router, err := getTestEnv()
+	if err != nil {
+		t.Error(err)
+	}
+
+	server.Start()
+	defer server.Close()
+
+	body := `{
+	  "sortOptions": [
+		{
+		  "sortOptionId": 1,
+		  "sortOptionName": "Relevance",
+		  "sortOptionKey": "Relevance",
+		  "links": []
+		},
+		{
+		  "sortOptionId": 2,
+		  "sortOptionName": "Rating",
+		  "sortOptionKey": "rating",
+		  "links": []
+		}
+	  ],
+	  "meta": { "itemCount": 2, "returncode": "OK" },
+	  "links": []
+	}`
+
+	expected := &SortOptions{
+		SortOptions: []SortOptionDetail{
+			{
+				SortOptionID:   1,
+				SortOptionName: "Relevance",
+				SortOptionKey:  "Relevance",
+				Links:          []Link{},
+			},
+			{
+				SortOptionID:   2,
+				SortOptionName: "Rating",
+				SortOptionKey:  "rating",
+				Links:          []Link{},
+			},
+		},
+		Meta:  Meta{ItemCount: 2, Returncode: "OK"},
+		Links: []Link{},
+	}
+
+	router.GET("/SortOptions", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	actual, err := client.SortOptions.Get()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nBut got:\n%+v\n", expected, actual)
+	}
+}
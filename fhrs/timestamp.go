@@ -0,0 +1,169 @@
+package fhrs
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampFormat is the format the API uses for timestamps, which has no
+// offset and is in UK time.
+const timestampFormat = "2006-01-02T15:04:05.9999999"
+
+// zeroTimestamp is how the API represents a missing timestamp.
+const zeroTimestamp = "0001-01-01T00:00:00"
+
+// ukLocation is the time zone of timestamps without an offset. If the time
+// zone database is not installed we fall back to UTC, which is only wrong
+// during British Summer Time, and ukLocationErr says why.
+var ukLocation, ukLocationErr = loadLocation("Europe/London")
+
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC, fmt.Errorf("Time zone %s is not available, UK times will be parsed as UTC: %v", name, err)
+	}
+
+	return loc, nil
+}
+
+// CheckTimeZone returns an error if the Europe/London time zone could not be
+// loaded, in which case timestamps are parsed as UTC and are an hour out
+// during British Summer Time.
+//
+// The time zone comes from the system's time zone database, which minimal
+// container images such as scratch and distroless leave out. Programs run in
+// them should check this at startup, and can embed the database by importing
+// time/tzdata (Go 1.15 and later).
+func CheckTimeZone() error {
+	return ukLocationErr
+}
+
+// Timestamp is a representation of the date/time format used throughout the API.
+//
+// It is mostly RFC3339 with the timezone omitted, but this is not consistent, so
+// both variations can be parsed as JSON. Timestamps without an offset are in UK
+// time, so are parsed in the Europe/London time zone. This needs the time zone
+// database, see CheckTimeZone.
+type Timestamp time.Time
+
+// ParseTimestamp parses a timestamp in any of the formats used by the API,
+// including the date-only format used by the open data files. Empty values
+// give the zero Timestamp. Values without an offset are in UK time, or UTC if
+// CheckTimeZone returns an error.
+func ParseTimestamp(s string) (Timestamp, error) {
+	s = strings.TrimSpace(s)
+	if s == "null" || s == "" || s == "undefined" {
+		return Timestamp{}, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		parsed, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+
+		// The zero time would gain an odd historical offset in UK time.
+		if parsed.IsZero() {
+			return Timestamp{}, nil
+		}
+
+		return Timestamp(time.Date(
+			parsed.Year(), parsed.Month(), parsed.Day(),
+			parsed.Hour(), parsed.Minute(), parsed.Second(), parsed.Nanosecond(),
+			ukLocation,
+		)), nil
+	}
+
+	// If we can't parse as the above, try RFC3339.
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return Timestamp{}, err
+	}
+
+	return Timestamp(parsed), nil
+}
+
+// Time returns the Timestamp as a time.Time.
+func (t Timestamp) Time() time.Time {
+	return time.Time(t)
+}
+
+// IsZero reports whether the Timestamp is missing.
+func (t Timestamp) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+func (t Timestamp) String() string {
+	ts := time.Time(t)
+	return ts.String()
+}
+
+// format returns the Timestamp in the API's format, in UK time.
+func (t Timestamp) format() string {
+	if t.IsZero() {
+		return zeroTimestamp
+	}
+
+	return time.Time(t).In(ukLocation).Format(timestampFormat)
+}
+
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	parsed, err := ParseTimestamp(strings.Trim(string(b), "\""))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the Timestamp in the API's format.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.format())), nil
+}
+
+func (t *Timestamp) UnmarshalText(b []byte) error {
+	parsed, err := ParseTimestamp(string(b))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// MarshalText encodes the Timestamp in the API's format.
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return []byte(t.format()), nil
+}
+
+// Scan implements sql.Scanner so a Timestamp can be read from a database.
+func (t *Timestamp) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = Timestamp{}
+		return nil
+	case time.Time:
+		*t = Timestamp(v)
+		return nil
+	case []byte:
+		return t.UnmarshalText(v)
+	case string:
+		return t.UnmarshalText([]byte(v))
+	}
+
+	return fmt.Errorf("Cannot scan %T into Timestamp", src)
+}
+
+// Value implements driver.Valuer so a Timestamp can be written to a database.
+// The zero Timestamp is written as NULL.
+func (t Timestamp) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+
+	return time.Time(t), nil
+}
@@ -0,0 +1,161 @@
+package fhrs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	summer := time.Date(2019, 8, 6, 12, 30, 0, 0, ukLocation)
+	winter := time.Date(2020, 1, 31, 0, 37, 40, 107000000, ukLocation)
+
+	cases := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "", want: time.Time{}},
+		{value: "null", want: time.Time{}},
+		{value: "0001-01-01T00:00:00", want: time.Time{}},
+		{value: "2019-08-06T12:30:00", want: summer},
+		{value: "2020-01-31T00:37:40.107", want: winter},
+		{value: "2019-08-06", want: time.Date(2019, 8, 6, 0, 0, 0, 0, ukLocation)},
+		{value: "2019-08-06T11:30:00Z", want: summer},
+		{value: "2020-02-03T22:32:34.2688747+00:00", want: time.Date(2020, 2, 3, 22, 32, 34, 268874700, time.UTC)},
+	}
+
+	for _, c := range cases {
+		have, err := ParseTimestamp(c.value)
+		if err != nil {
+			t.Errorf("Expected %q to parse but got %v", c.value, err)
+			continue
+		}
+
+		if !have.Time().Equal(c.want) {
+			t.Errorf("Expected %q to give %s but got %s", c.value, c.want, have)
+		}
+	}
+
+	if _, err := ParseTimestamp("yesterday"); err == nil {
+		t.Error("Expected an error for an invalid timestamp")
+	}
+
+	if ts, _ := ParseTimestamp(""); !ts.IsZero() {
+		t.Error("Expected an empty timestamp to be zero")
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	est := Establishment{
+		RatingDate: Timestamp(time.Date(2019, 8, 6, 0, 0, 0, 0, ukLocation)),
+		Meta: Meta{
+			ExtractDate: Timestamp(time.Date(2020, 2, 3, 22, 32, 34, 268874700, time.UTC)),
+		},
+	}
+
+	b, err := json.Marshal(est)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Establishment
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.RatingDate.Time().Equal(est.RatingDate.Time()) {
+		t.Errorf("Expected RatingDate to round trip as %s but got %s", est.RatingDate, decoded.RatingDate)
+	}
+
+	if !decoded.Meta.ExtractDate.Time().Equal(est.Meta.ExtractDate.Time()) {
+		t.Errorf("Expected ExtractDate to round trip as %s but got %s", est.Meta.ExtractDate, decoded.Meta.ExtractDate)
+	}
+
+	cases := []struct {
+		ts   Timestamp
+		want string
+	}{
+		{ts: Timestamp{}, want: `"0001-01-01T00:00:00"`},
+		{ts: Timestamp(time.Date(2019, 8, 6, 0, 0, 0, 0, ukLocation)), want: `"2019-08-06T00:00:00"`},
+		{ts: Timestamp(time.Date(2019, 8, 5, 23, 0, 0, 0, time.UTC)), want: `"2019-08-06T00:00:00"`},
+		{ts: Timestamp(time.Date(2020, 1, 31, 0, 37, 40, 107000000, ukLocation)), want: `"2020-01-31T00:37:40.107"`},
+	}
+
+	for _, c := range cases {
+		b, err := json.Marshal(c.ts)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if string(b) != c.want {
+			t.Errorf("Expected %s but got %s", c.want, b)
+		}
+	}
+}
+
+func TestTimestampText(t *testing.T) {
+	var ts Timestamp
+	if err := ts.UnmarshalText([]byte("2019-08-06T00:00:00")); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ts.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "2019-08-06T00:00:00" {
+		t.Errorf("Expected 2019-08-06T00:00:00 but got %s", b)
+	}
+}
+
+func TestTimestampSQL(t *testing.T) {
+	want := time.Date(2019, 8, 6, 0, 0, 0, 0, ukLocation)
+
+	for _, src := range []interface{}{want, "2019-08-06T00:00:00", []byte("2019-08-06T00:00:00")} {
+		var ts Timestamp
+		if err := ts.Scan(src); err != nil {
+			t.Errorf("Expected %T to scan but got %v", src, err)
+		}
+
+		if !ts.Time().Equal(want) {
+			t.Errorf("Expected %T to scan as %s but got %s", src, want, ts)
+		}
+	}
+
+	var ts Timestamp
+	if err := ts.Scan(nil); err != nil || !ts.IsZero() {
+		t.Errorf("Expected NULL to scan as zero but got %s, %v", ts, err)
+	}
+
+	if err := ts.Scan(42); err == nil {
+		t.Error("Expected an error scanning an int")
+	}
+
+	if v, err := (Timestamp{}).Value(); v != nil || err != nil {
+		t.Errorf("Expected zero to be NULL but got %v, %v", v, err)
+	}
+
+	if v, err := Timestamp(want).Value(); v != want || err != nil {
+		t.Errorf("Expected %s but got %v, %v", want, v, err)
+	}
+}
+
+func TestCheckTimeZone(t *testing.T) {
+	if err := CheckTimeZone(); err != nil {
+		if ukLocation != time.UTC {
+			t.Errorf("Expected the fallback to be UTC but got %s", ukLocation)
+		}
+	} else if ukLocation.String() != "Europe/London" {
+		t.Errorf("Expected Europe/London but got %s", ukLocation)
+	}
+
+	loc, err := loadLocation("Nowhere/Missing")
+	if err == nil {
+		t.Error("Expected an error for a missing time zone")
+	}
+
+	if loc != time.UTC {
+		t.Errorf("Expected a missing time zone to fall back to UTC but got %s", loc)
+	}
+}